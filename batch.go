@@ -0,0 +1,218 @@
+package sender
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	grp "github.com/cmd-stream/cmd-stream-go/group"
+	"github.com/cmd-stream/core-go"
+)
+
+// BatchHooks are called once per batch, in addition to the hooks of each
+// Command, see WithBatchHooks.
+type BatchHooks interface {
+	// BeforeBatch is called before the batch is sent, if it returns an error,
+	// the batch is not sent.
+	BeforeBatch(ctx context.Context, size int) (context.Context, error)
+	// AfterBatch is called when all Commands of the batch are completed.
+	AfterBatch(ctx context.Context, results []BatchResult, err error)
+}
+
+type BatchOptions struct {
+	Pin            bool
+	FailFast       bool
+	MaxParallelism int
+	Hooks          BatchHooks
+}
+
+type SetBatchOption func(o *BatchOptions)
+
+// WithPinning makes all Commands of the batch to be sent through the same
+// client, so that they reach the server in order. The client group must
+// implement BroadcastGroup.
+func WithPinning() SetBatchOption {
+	return func(o *BatchOptions) { o.Pin = true }
+}
+
+// WithFailFast makes SendBatch to stop on the first failed Command: the rest
+// are not sent or forgotten, and complete with ErrCanceled. By default, all
+// Commands are completed and all errors are collected.
+func WithFailFast() SetBatchOption {
+	return func(o *BatchOptions) { o.FailFast = true }
+}
+
+// WithMaxParallelism limits the number of Commands of the batch that wait for
+// their Results at the same time. By default, there is no limit.
+func WithMaxParallelism(n int) SetBatchOption {
+	return func(o *BatchOptions) { o.MaxParallelism = n }
+}
+
+// WithBatchHooks sets the hooks called once per batch.
+func WithBatchHooks(hooks BatchHooks) SetBatchOption {
+	return func(o *BatchOptions) { o.Hooks = hooks }
+}
+
+func ApplyBatch(ops []SetBatchOption, o *BatchOptions) {
+	for i := range ops {
+		if ops[i] != nil {
+			ops[i](o)
+		}
+	}
+}
+
+// BatchResult is the outcome of a single Command of the batch.
+type BatchResult struct {
+	Result core.Result
+	Err    error
+}
+
+// SendBatch sends the Commands back-to-back and waits (using the ctx) for
+// their Results. The results slice is indexed as the cmds one.
+//
+// Commands are sent in order, hooks are called for each of them. Retry,
+// hedging, coalescing and caching are not applied. Returns *BatchError for
+// the first failed Command (with WithFailFast), or all of them joined
+// together.
+func (s Sender[T]) SendBatch(ctx context.Context, cmds []core.Cmd[T],
+	ops ...SetBatchOption,
+) (results []BatchResult, err error) {
+	if !s.tracker.acquire() {
+		return nil, ErrClosed
+	}
+	defer s.tracker.release()
+	o := BatchOptions{}
+	ApplyBatch(ops, &o)
+	var group BroadcastGroup[T]
+	if o.Pin {
+		var ok bool
+		if group, ok = s.group.(BroadcastGroup[T]); !ok {
+			return nil, ErrPinningUnsupported
+		}
+	}
+	if o.Hooks != nil {
+		if ctx, err = o.Hooks.BeforeBatch(ctx, len(cmds)); err != nil {
+			return
+		}
+		defer func() { o.Hooks.AfterBatch(ctx, results, err) }()
+	}
+	b := newBatch(ctx, len(cmds), o)
+	defer b.cancel(nil)
+	var (
+		pinned   bool
+		clientID grp.ClientID
+	)
+	for i, cmd := range cmds {
+		if !b.acquire() {
+			b.results[i].Err = b.canceledErr()
+			continue
+		}
+		results := make(chan core.AsyncResult, 1)
+		f, err := s.dispatchWith(b.ctx, cmd, time.Time{},
+			func(deadline time.Time) (core.Seq, grp.ClientID, int, error) {
+				if !pinned {
+					return s.groupSend(cmd, results, deadline)
+				}
+				seq, n, err := s.sendTo(group, clientID, cmd, results, deadline)
+				return seq, clientID, n, err
+			})
+		if err != nil {
+			b.complete(i, nil, err)
+			continue
+		}
+		if o.Pin && !pinned {
+			pinned, clientID = true, f.clientID
+		}
+		b.wg.Add(1)
+		go func() {
+			defer b.wg.Done()
+			result, err := s.receive(f, results)
+			b.complete(i, result, err)
+		}()
+	}
+	b.wg.Wait()
+	return b.results, b.err()
+}
+
+func newBatch(ctx context.Context, size int, o BatchOptions) *batch {
+	b := &batch{options: o, results: make([]BatchResult, size), failed: -1}
+	b.ctx, b.cancel = context.WithCancelCause(ctx)
+	if o.MaxParallelism > 0 {
+		b.sem = make(chan struct{}, o.MaxParallelism)
+	}
+	return b
+}
+
+// batch keeps the state of SendBatch.
+type batch struct {
+	options BatchOptions
+	ctx     context.Context
+	cancel  context.CancelCauseFunc
+	sem     chan struct{}
+	wg      sync.WaitGroup
+	results []BatchResult
+
+	mu     sync.Mutex
+	failed int
+}
+
+// acquire waits for a free slot if the parallelism is limited. Returns false
+// if the batch is canceled or the ctx is done.
+func (b *batch) acquire() bool {
+	if b.sem != nil {
+		select {
+		case b.sem <- struct{}{}:
+		case <-b.ctx.Done():
+			return false
+		}
+	}
+	if b.ctx.Err() != nil {
+		b.release()
+		return false
+	}
+	return true
+}
+
+func (b *batch) release() {
+	if b.sem != nil {
+		<-b.sem
+	}
+}
+
+// complete sets the outcome of the i-th Command. With fail fast, the first
+// error cancels the batch.
+func (b *batch) complete(i int, result core.Result, err error) {
+	b.results[i] = BatchResult{result, err}
+	b.release()
+	if err == nil || !b.options.FailFast {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.failed == -1 && err != ErrCanceled {
+		b.failed = i
+		b.cancel(ErrCanceled)
+	}
+}
+
+// canceledErr returns the error of Commands that were not sent.
+func (b *batch) canceledErr() error {
+	if context.Cause(b.ctx) == ErrCanceled {
+		return ErrCanceled
+	}
+	return ErrTimeout
+}
+
+func (b *batch) err() error {
+	if b.options.FailFast && b.failed != -1 {
+		return NewBatchError(b.failed, b.results[b.failed].Err)
+	}
+	var errs []error
+	for i := range b.results {
+		if b.results[i].Err != nil {
+			errs = append(errs, NewBatchError(i, b.results[i].Err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,137 @@
+package sender_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	grp "github.com/cmd-stream/cmd-stream-go/group"
+	"github.com/cmd-stream/core-go"
+	sndr "github.com/cmd-stream/sender-go"
+	"github.com/cmd-stream/sender-go/test/helpers"
+	"github.com/cmd-stream/sender-go/test/mocks"
+	cmocks "github.com/cmd-stream/testkit-go/mocks/core"
+	asserterror "github.com/ymz-ncnk/assert/error"
+	assertfatal "github.com/ymz-ncnk/assert/fatal"
+	"github.com/ymz-ncnk/mok"
+)
+
+func TestSendBatch(t *testing.T) {
+	t.Run("Should pin the batch to one client", func(t *testing.T) {
+		addr1, server1, err := helpers.StartServer()
+		assertfatal.EqualError(err, nil, t)
+		defer server1.Close()
+		addr2, server2, err := helpers.StartServer()
+		assertfatal.EqualError(err, nil, t)
+		defer server2.Close()
+
+		sender, err := sndr.MakeMulti([]string{addr1, addr2},
+			helpers.ClientCodec{})
+		assertfatal.EqualError(err, nil, t)
+		defer sender.Close()
+
+		cmds := make([]core.Cmd[struct{}], 4)
+		for i := range cmds {
+			cmds[i] = helpers.AddrCmd{}
+		}
+		results, err := sender.SendBatch(context.Background(), cmds,
+			sndr.WithPinning(), sndr.WithMaxParallelism(2))
+		assertfatal.EqualError(err, nil, t)
+		asserterror.Equal(len(results), 4, t)
+		for i := range results {
+			asserterror.Equal(results[i].Result, results[0].Result, t)
+		}
+
+		results, err = sender.SendBatch(context.Background(), cmds)
+		assertfatal.EqualError(err, nil, t)
+		asserterror.Equal(results[0].Result != results[1].Result, true, t)
+	})
+
+	t.Run("Should collect all errors", func(t *testing.T) {
+		var (
+			wantErr    = errors.New("ClientGroup.Send error")
+			wantResult = cmocks.NewResult()
+			group      = mocks.NewClientGroup().RegisterSend(
+				func(cmd core.Cmd[any], results chan<- core.AsyncResult) (
+					seq core.Seq, clientID grp.ClientID, n int, err error,
+				) {
+					return 0, 0, 0, wantErr
+				},
+			).RegisterSend(
+				func(cmd core.Cmd[any], results chan<- core.AsyncResult) (
+					seq core.Seq, clientID grp.ClientID, n int, err error,
+				) {
+					results <- core.AsyncResult{Result: wantResult}
+					return 1, 0, 10, nil
+				},
+			)
+			batchHooks = &countingBatchHooks{}
+			sender     = sndr.New[any](group)
+			mocks      = []*mok.Mock{group.Mock}
+		)
+		results, err := sender.SendBatch(context.Background(),
+			[]core.Cmd[any]{cmocks.NewCmd(), cmocks.NewCmd()},
+			sndr.WithBatchHooks(batchHooks))
+		asserterror.EqualError(err, errors.Join(sndr.NewBatchError(0, wantErr)),
+			t)
+		asserterror.EqualDeep(results, []sndr.BatchResult{
+			{Err: wantErr},
+			{Result: wantResult},
+		}, t)
+		asserterror.Equal(batchHooks.size, 2, t)
+		asserterror.Equal(len(batchHooks.results), 2, t)
+
+		asserterror.EqualDeep(mok.CheckCalls(mocks), mok.EmptyInfomap, t)
+	})
+
+	t.Run("Should stop on the first error with WithFailFast",
+		func(t *testing.T) {
+			var (
+				wantErr = errors.New("ClientGroup.Send error")
+				group   = mocks.NewClientGroup().RegisterSend(
+					func(cmd core.Cmd[any], results chan<- core.AsyncResult) (
+						seq core.Seq, clientID grp.ClientID, n int, err error,
+					) {
+						return 0, 0, 0, wantErr
+					},
+				)
+				sender = sndr.New[any](group)
+				mocks  = []*mok.Mock{group.Mock}
+			)
+			results, err := sender.SendBatch(context.Background(),
+				[]core.Cmd[any]{cmocks.NewCmd(), cmocks.NewCmd()},
+				sndr.WithFailFast())
+			asserterror.EqualError(err, sndr.NewBatchError(0, wantErr), t)
+			asserterror.EqualDeep(results, []sndr.BatchResult{
+				{Err: wantErr},
+				{Err: sndr.ErrCanceled},
+			}, t)
+
+			asserterror.EqualDeep(mok.CheckCalls(mocks), mok.EmptyInfomap, t)
+		})
+
+	t.Run("Should return ErrPinningUnsupported", func(t *testing.T) {
+		sender := sndr.New[any](mocks.NewClientGroup())
+		_, err := sender.SendBatch(context.Background(),
+			[]core.Cmd[any]{cmocks.NewCmd()}, sndr.WithPinning())
+		asserterror.EqualError(err, sndr.ErrPinningUnsupported, t)
+	})
+}
+
+type countingBatchHooks struct {
+	size    int
+	results []sndr.BatchResult
+}
+
+func (h *countingBatchHooks) BeforeBatch(ctx context.Context, size int) (
+	context.Context, error,
+) {
+	h.size = size
+	return ctx, nil
+}
+
+func (h *countingBatchHooks) AfterBatch(ctx context.Context,
+	results []sndr.BatchResult, err error,
+) {
+	h.results = results
+}
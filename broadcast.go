@@ -0,0 +1,159 @@
+package sender
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	grp "github.com/cmd-stream/cmd-stream-go/group"
+	"github.com/cmd-stream/core-go"
+)
+
+type BroadcastOptions struct {
+	Quorum int
+}
+
+type SetBroadcastOption func(o *BroadcastOptions)
+
+// WithQuorum sets the number of clients that must succeed for Broadcast to
+// succeed. Once it is reached, Broadcast returns and the Command is forgotten
+// by the remaining clients. By default, all clients must succeed.
+func WithQuorum(quorum int) SetBroadcastOption {
+	return func(o *BroadcastOptions) { o.Quorum = quorum }
+}
+
+func ApplyBroadcast(ops []SetBroadcastOption, o *BroadcastOptions) {
+	for i := range ops {
+		if ops[i] != nil {
+			ops[i](o)
+		}
+	}
+}
+
+// ClientResult is the outcome of a broadcast Command for a single client.
+type ClientResult struct {
+	Result core.Result
+	Err    error
+}
+
+// BroadcastResult contains the outcome of a broadcast Command for each client.
+// Clients that were still waiting for the Result when the quorum was reached
+// have Err == ErrCanceled.
+type BroadcastResult map[grp.ClientID]ClientResult
+
+// Succeeded returns the number of clients that received the Result.
+func (r BroadcastResult) Succeeded() (n int) {
+	for _, cr := range r {
+		if cr.Err == nil {
+			n++
+		}
+	}
+	return
+}
+
+// Broadcast sends a Command through every client of the group and waits
+// (using the ctx) for their Results, see WithQuorum. The group must implement
+// BroadcastGroup.
+//
+// Hooks are called for each client separately. Retry and hedging are not
+// applied. If the quorum is not reached, *QuorumError is returned along with
+// the BroadcastResult.
+func (s Sender[T]) Broadcast(ctx context.Context, cmd core.Cmd[T],
+	ops ...SetBroadcastOption,
+) (result BroadcastResult, err error) {
+	return s.broadcast(ctx, cmd, time.Time{}, ops)
+}
+
+// BroadcastWithDeadline is like Broadcast, but sends the Command with the
+// specified deadline.
+func (s Sender[T]) BroadcastWithDeadline(ctx context.Context, cmd core.Cmd[T],
+	deadline time.Time,
+	ops ...SetBroadcastOption,
+) (result BroadcastResult, err error) {
+	return s.broadcast(ctx, cmd, deadline, ops)
+}
+
+func (s Sender[T]) broadcast(ctx context.Context, cmd core.Cmd[T],
+	deadline time.Time,
+	ops []SetBroadcastOption,
+) (result BroadcastResult, err error) {
+	if !s.tracker.acquire() {
+		return nil, ErrClosed
+	}
+	defer s.tracker.release()
+	group, ok := s.group.(BroadcastGroup[T])
+	if !ok {
+		return nil, ErrBroadcastUnsupported
+	}
+	ids := group.ClientIDs()
+	if len(ids) == 0 {
+		return nil, ErrNoClients
+	}
+	o := BroadcastOptions{}
+	ApplyBroadcast(ops, &o)
+	quorum := o.Quorum
+	if quorum <= 0 || quorum > len(ids) {
+		quorum = len(ids)
+	}
+	var (
+		cctx, cancel = context.WithCancelCause(ctx)
+		outcomes     = make(chan clientOutcome, len(ids))
+		pending      int
+	)
+	defer cancel(nil)
+	result = make(BroadcastResult, len(ids))
+	for _, id := range ids {
+		results := make(chan core.AsyncResult, 1)
+		f, err := s.dispatchWith(cctx, cmd, deadline,
+			func(deadline time.Time) (core.Seq, grp.ClientID, int, error) {
+				seq, n, err := s.sendTo(group, id, cmd, results, deadline)
+				return seq, id, n, err
+			})
+		if err != nil {
+			result[id] = ClientResult{Err: err}
+			continue
+		}
+		pending++
+		go func() {
+			r, err := s.receive(f, results)
+			outcomes <- clientOutcome{id, ClientResult{r, err}}
+		}()
+	}
+	var succeeded int
+	for ; pending > 0; pending-- {
+		o := <-outcomes
+		result[o.clientID] = o.ClientResult
+		if o.Err != nil {
+			continue
+		}
+		if succeeded++; succeeded == quorum {
+			cancel(ErrCanceled)
+		}
+	}
+	if succeeded < quorum {
+		var errs []error
+		for _, cr := range result {
+			if cr.Err != nil {
+				errs = append(errs, cr.Err)
+			}
+		}
+		err = NewQuorumError(succeeded, quorum, errors.Join(errs...))
+	}
+	return
+}
+
+func (s Sender[T]) sendTo(group BroadcastGroup[T], clientID grp.ClientID,
+	cmd core.Cmd[T],
+	results chan<- core.AsyncResult,
+	deadline time.Time,
+) (seq core.Seq, n int, err error) {
+	if deadline.IsZero() {
+		return group.SendTo(clientID, cmd, results)
+	}
+	return group.SendToWithDeadline(clientID, cmd, results, deadline)
+}
+
+type clientOutcome struct {
+	clientID grp.ClientID
+	ClientResult
+}
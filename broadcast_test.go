@@ -0,0 +1,143 @@
+package sender_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	grp "github.com/cmd-stream/cmd-stream-go/group"
+	"github.com/cmd-stream/core-go"
+	sndr "github.com/cmd-stream/sender-go"
+	"github.com/cmd-stream/sender-go/test/helpers"
+	"github.com/cmd-stream/sender-go/test/mocks"
+	cmocks "github.com/cmd-stream/testkit-go/mocks/core"
+	asserterror "github.com/ymz-ncnk/assert/error"
+	assertfatal "github.com/ymz-ncnk/assert/fatal"
+	"github.com/ymz-ncnk/mok"
+)
+
+func TestBroadcast(t *testing.T) {
+	t.Run("Should send the Command through every client", func(t *testing.T) {
+		var addrs []string
+		for range 3 {
+			addr, server, err := helpers.StartServer()
+			assertfatal.EqualError(err, nil, t)
+			defer server.Close()
+			addrs = append(addrs, addr)
+		}
+		sender, err := sndr.MakeMulti(addrs, helpers.ClientCodec{})
+		assertfatal.EqualError(err, nil, t)
+		defer sender.Close()
+
+		result, err := sender.Broadcast(context.Background(), helpers.AddrCmd{})
+		assertfatal.EqualError(err, nil, t)
+		asserterror.EqualDeep(result, sndr.BroadcastResult{
+			0: {Result: helpers.AddrResult(addrs[0])},
+			1: {Result: helpers.AddrResult(addrs[1])},
+			2: {Result: helpers.AddrResult(addrs[2])},
+		}, t)
+	})
+
+	t.Run("Should forget the Command once the quorum is reached",
+		func(t *testing.T) {
+			var (
+				wantResult = cmocks.NewResult()
+				group      = mocks.NewBroadcastGroup().RegisterClientIDs(
+					func() []grp.ClientID { return []grp.ClientID{0, 1, 2} },
+				).RegisterNSendTo(2,
+					func(clientID grp.ClientID, cmd core.Cmd[any],
+						results chan<- core.AsyncResult,
+					) (seq core.Seq, n int, err error) {
+						results <- core.AsyncResult{Result: wantResult}
+						return 1, 10, nil
+					},
+				).RegisterSendTo(
+					func(clientID grp.ClientID, cmd core.Cmd[any],
+						results chan<- core.AsyncResult,
+					) (seq core.Seq, n int, err error) {
+						return 1, 10, nil
+					},
+				).RegisterForget(
+					func(seq core.Seq, clientID grp.ClientID) {
+						asserterror.Equal(clientID, 2, t)
+					},
+				)
+				sender = sndr.New[any](group)
+				mocks  = []*mok.Mock{group.Mock}
+			)
+			result, err := sender.Broadcast(context.Background(), cmocks.NewCmd(),
+				sndr.WithQuorum(2))
+			asserterror.EqualError(err, nil, t)
+			asserterror.Equal(result.Succeeded(), 2, t)
+			asserterror.EqualDeep(result[2], sndr.ClientResult{Err: sndr.ErrCanceled},
+				t)
+
+			asserterror.EqualDeep(mok.CheckCalls(mocks), mok.EmptyInfomap, t)
+		})
+
+	t.Run("Should return QuorumError", func(t *testing.T) {
+		var (
+			wantErr = errors.New("SendTo error")
+			group   = mocks.NewBroadcastGroup().RegisterClientIDs(
+				func() []grp.ClientID { return []grp.ClientID{0, 1} },
+			).RegisterSendTo(
+				func(clientID grp.ClientID, cmd core.Cmd[any],
+					results chan<- core.AsyncResult,
+				) (seq core.Seq, n int, err error) {
+					return 0, 0, wantErr
+				},
+			).RegisterSendTo(
+				func(clientID grp.ClientID, cmd core.Cmd[any],
+					results chan<- core.AsyncResult,
+				) (seq core.Seq, n int, err error) {
+					results <- core.AsyncResult{Result: cmocks.NewResult()}
+					return 1, 10, nil
+				},
+			)
+			sender = sndr.New[any](group)
+			mocks  = []*mok.Mock{group.Mock}
+		)
+		result, err := sender.Broadcast(context.Background(), cmocks.NewCmd())
+		asserterror.EqualError(err, sndr.NewQuorumError(1, 2, wantErr), t)
+		asserterror.Equal(errors.Is(err, wantErr), true, t)
+		asserterror.EqualDeep(result[0], sndr.ClientResult{Err: wantErr}, t)
+
+		asserterror.EqualDeep(mok.CheckCalls(mocks), mok.EmptyInfomap, t)
+	})
+
+	t.Run("QuorumError should keep ErrCanceled of the ctx",
+		func(t *testing.T) {
+			var (
+				group = mocks.NewBroadcastGroup().RegisterClientIDs(
+					func() []grp.ClientID { return []grp.ClientID{0} },
+				).RegisterSendTo(
+					func(clientID grp.ClientID, cmd core.Cmd[any],
+						results chan<- core.AsyncResult,
+					) (seq core.Seq, n int, err error) {
+						return 1, 10, nil
+					},
+				).RegisterForget(
+					func(seq core.Seq, clientID grp.ClientID) {},
+				)
+				sender      = sndr.New[any](group)
+				mocks       = []*mok.Mock{group.Mock}
+				ctx, cancel = context.WithCancelCause(context.Background())
+			)
+			time.AfterFunc(10*time.Millisecond, func() { cancel(sndr.ErrCanceled) })
+			result, err := sender.Broadcast(ctx, cmocks.NewCmd())
+			asserterror.EqualError(err,
+				sndr.NewQuorumError(0, 1, errors.Join(sndr.ErrCanceled)), t)
+			asserterror.Equal(errors.Is(err, sndr.ErrCanceled), true, t)
+			asserterror.EqualDeep(result[0], sndr.ClientResult{Err: sndr.ErrCanceled},
+				t)
+
+			asserterror.EqualDeep(mok.CheckCalls(mocks), mok.EmptyInfomap, t)
+		})
+
+	t.Run("Should return ErrBroadcastUnsupported", func(t *testing.T) {
+		sender := sndr.New[any](mocks.NewClientGroup())
+		_, err := sender.Broadcast(context.Background(), cmocks.NewCmd())
+		asserterror.EqualError(err, sndr.ErrBroadcastUnsupported, t)
+	})
+}
@@ -0,0 +1,178 @@
+package sender
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/cmd-stream/core-go"
+	hks "github.com/cmd-stream/sender-go/hooks"
+)
+
+// Cacheable is implemented by Commands whose Results can be cached, see
+// WithCache.
+type Cacheable interface {
+	CacheKey() string
+	// TTL returns how long the cached Result stays fresh.
+	TTL() time.Duration
+}
+
+// CacheEntry is a cached Result, or an error if negative caching is enabled.
+type CacheEntry struct {
+	Result core.Result
+	Err    error
+	// Expires is the time the entry stops being fresh.
+	Expires time.Time
+	// StaleUntil is the time until which the expired entry can be served
+	// while it is revalidated.
+	StaleUntil time.Time
+}
+
+// CacheStore stores cache entries. It must be safe for concurrent use.
+type CacheStore interface {
+	Get(key string) (entry CacheEntry, ok bool)
+	Set(key string, entry CacheEntry)
+	Delete(key string)
+}
+
+type CacheOptions struct {
+	Store                CacheStore
+	StaleWhileRevalidate time.Duration
+	NegativeTTL          time.Duration
+	Negative             func(err error) bool
+	Clock                hks.Clock
+}
+
+type SetCacheOption func(o *CacheOptions)
+
+// WithStaleWhileRevalidate allows to serve an expired Result for the
+// specified time after its expiration, while it is revalidated in the
+// background.
+func WithStaleWhileRevalidate(d time.Duration) SetCacheOption {
+	return func(o *CacheOptions) { o.StaleWhileRevalidate = d }
+}
+
+// WithNegativeCaching enables caching of the specified errors (compared with
+// errors.Is) for the ttl. By default, errors are not cached.
+func WithNegativeCaching(ttl time.Duration, errs ...error) SetCacheOption {
+	return func(o *CacheOptions) {
+		o.NegativeTTL = ttl
+		o.Negative = func(err error) bool {
+			for i := range errs {
+				if errors.Is(err, errs[i]) {
+					return true
+				}
+			}
+			return false
+		}
+	}
+}
+
+// WithCacheClock sets the clock used to check the expiration of entries.
+func WithCacheClock(clock hks.Clock) SetCacheOption {
+	return func(o *CacheOptions) { o.Clock = clock }
+}
+
+func ApplyCache(ops []SetCacheOption, o *CacheOptions) {
+	for i := range ops {
+		if ops[i] != nil {
+			ops[i](o)
+		}
+	}
+}
+
+func newCache(o CacheOptions) *cache {
+	return &cache{options: o, revalidating: map[string]struct{}{}}
+}
+
+// cache keeps track of the keys being revalidated, so that each key is
+// revalidated only once at a time.
+type cache struct {
+	options      CacheOptions
+	mu           sync.Mutex
+	revalidating map[string]struct{}
+}
+
+func (c *cache) startRevalidation(key string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, pst := c.revalidating[key]; pst {
+		return false
+	}
+	c.revalidating[key] = struct{}{}
+	return true
+}
+
+func (c *cache) endRevalidation(key string) {
+	c.mu.Lock()
+	delete(c.revalidating, key)
+	c.mu.Unlock()
+}
+
+// sendCached returns the cached Result of the Command if it is fresh, or
+// stale but can still be served (then it is revalidated in the background).
+// Otherwise, the Command is sent and its Result is cached.
+func (s Sender[T]) sendCached(ctx context.Context, cmd core.Cmd[T],
+	cacheable Cacheable,
+	deadline time.Time,
+) (result core.Result, err error) {
+	var (
+		key        = cacheable.CacheKey()
+		now        = s.cache.options.Clock.Now()
+		entry, pst = s.cache.options.Store.Get(key)
+	)
+	if pst && now.Before(entry.StaleUntil) {
+		stale := !now.Before(entry.Expires)
+		if stale && s.cache.startRevalidation(key) {
+			go s.revalidate(context.WithoutCancel(ctx), cmd, cacheable)
+		}
+		hks.OnCacheHit(s.options.HooksFactory.New(), ctx, cmd, hks.CacheHit{
+			Key:    key,
+			Result: entry.Result,
+			Err:    entry.Err,
+			Stale:  stale,
+		})
+		return entry.Result, entry.Err
+	}
+	if pst {
+		s.cache.options.Store.Delete(key)
+	}
+	result, err = s.sendUncached(ctx, cmd, deadline)
+	s.store(cacheable, result, err)
+	return
+}
+
+// revalidate sends the Command and caches its Result. It waits for the Result
+// no longer than the stale-while-revalidate time.
+func (s Sender[T]) revalidate(ctx context.Context, cmd core.Cmd[T],
+	cacheable Cacheable,
+) {
+	defer s.cache.endRevalidation(cacheable.CacheKey())
+	if !s.tracker.acquire() {
+		return
+	}
+	defer s.tracker.release()
+	ctx, cancel := context.WithTimeout(ctx,
+		s.cache.options.StaleWhileRevalidate)
+	defer cancel()
+	result, err := s.sendUncached(ctx, cmd, time.Time{})
+	s.store(cacheable, result, err)
+}
+
+func (s Sender[T]) store(cacheable Cacheable, result core.Result, err error) {
+	ttl := cacheable.TTL()
+	if err != nil {
+		if s.cache.options.Negative == nil || !s.cache.options.Negative(err) {
+			return
+		}
+		ttl = s.cache.options.NegativeTTL
+	}
+	expires := s.cache.options.Clock.Now().Add(ttl)
+	s.cache.options.Store.Set(cacheable.CacheKey(), CacheEntry{
+		Result:     result,
+		Err:        err,
+		Expires:    expires,
+		StaleUntil: expires.Add(s.cache.options.StaleWhileRevalidate),
+	})
+}
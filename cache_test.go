@@ -0,0 +1,197 @@
+package sender_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	grp "github.com/cmd-stream/cmd-stream-go/group"
+	"github.com/cmd-stream/core-go"
+	sndr "github.com/cmd-stream/sender-go"
+	hks "github.com/cmd-stream/sender-go/hooks"
+	"github.com/cmd-stream/sender-go/test/helpers"
+	"github.com/cmd-stream/sender-go/test/mocks"
+	cmocks "github.com/cmd-stream/testkit-go/mocks/core"
+	asserterror "github.com/ymz-ncnk/assert/error"
+	"github.com/ymz-ncnk/mok"
+)
+
+type cacheableCmd struct {
+	core.Cmd[any]
+	key string
+}
+
+func (c cacheableCmd) CacheKey() string   { return c.key }
+func (c cacheableCmd) TTL() time.Duration { return time.Second }
+
+func TestCache(t *testing.T) {
+	sendResult := func(result core.Result) mocks.SendFn {
+		return func(cmd core.Cmd[any], results chan<- core.AsyncResult) (
+			seq core.Seq, clientID grp.ClientID, n int, err error,
+		) {
+			results <- core.AsyncResult{Result: result}
+			return 1, 0, 10, nil
+		}
+	}
+
+	t.Run("Should serve a fresh Result from the cache", func(t *testing.T) {
+		var (
+			wantResult = cmocks.NewResult()
+			clock      = helpers.NewClock(time.Unix(0, 0))
+			group      = mocks.NewClientGroup().RegisterSend(sendResult(wantResult))
+			sender     = sndr.New(group, sndr.WithCache[any](sndr.NewLRUStore(10),
+				sndr.WithCacheClock(clock)))
+			cmd   = cacheableCmd{cmocks.NewCmd(), "key"}
+			mocks = []*mok.Mock{group.Mock}
+		)
+		for range 2 {
+			result, err := sender.Send(context.Background(), cmd)
+			asserterror.EqualError(err, nil, t)
+			asserterror.EqualDeep(result, core.Result(wantResult), t)
+		}
+		asserterror.EqualDeep(mok.CheckCalls(mocks), mok.EmptyInfomap, t)
+	})
+
+	t.Run("Should send the Command again once the Result expires",
+		func(t *testing.T) {
+			var (
+				result1 = cmocks.NewResult()
+				result2 = cmocks.NewResult()
+				clock   = helpers.NewClock(time.Unix(0, 0))
+				group   = mocks.NewClientGroup().RegisterSend(
+					sendResult(result1),
+				).RegisterSend(sendResult(result2))
+				sender = sndr.New(group, sndr.WithCache[any](sndr.NewLRUStore(10),
+					sndr.WithCacheClock(clock)))
+				cmd   = cacheableCmd{cmocks.NewCmd(), "key"}
+				mocks = []*mok.Mock{group.Mock}
+			)
+			sender.Send(context.Background(), cmd)
+			clock.Advance(time.Second)
+			result, err := sender.Send(context.Background(), cmd)
+			asserterror.EqualError(err, nil, t)
+			asserterror.Equal(result == result2, true, t)
+
+			asserterror.EqualDeep(mok.CheckCalls(mocks), mok.EmptyInfomap, t)
+		})
+
+	t.Run("Should serve a stale Result while revalidating", func(t *testing.T) {
+		var (
+			result1 = cmocks.NewResult()
+			result2 = cmocks.NewResult()
+			clock   = helpers.NewClock(time.Unix(0, 0))
+			group   = mocks.NewClientGroup().RegisterSend(
+				sendResult(result1),
+			).RegisterSend(sendResult(result2))
+			hooks  = &cacheHooks{NoopHooks: hks.NoopHooks[any]{}}
+			sender = sndr.New(group,
+				sndr.WithCache[any](sndr.NewLRUStore(10),
+					sndr.WithCacheClock(clock),
+					sndr.WithStaleWhileRevalidate(time.Minute),
+				),
+				sndr.WithHooksFactory[any](hooks),
+			)
+			cmd   = cacheableCmd{cmocks.NewCmd(), "key"}
+			mocks = []*mok.Mock{group.Mock}
+		)
+		sender.Send(context.Background(), cmd)
+		clock.Advance(2 * time.Second)
+		result, err := sender.Send(context.Background(), cmd)
+		asserterror.EqualError(err, nil, t)
+		asserterror.Equal(result == result1, true, t)
+		eventually(t, func() bool {
+			result, _ := sender.Send(context.Background(), cmd)
+			return result == result2
+		})
+		asserterror.Equal(hooks.stale.Load() >= 1, true, t)
+
+		asserterror.EqualDeep(mok.CheckCalls(mocks), mok.EmptyInfomap, t)
+	})
+
+	t.Run("Should cache the specified errors", func(t *testing.T) {
+		var (
+			wantErr = errors.New("not found")
+			group   = mocks.NewClientGroup().RegisterSend(
+				func(cmd core.Cmd[any], results chan<- core.AsyncResult) (
+					seq core.Seq, clientID grp.ClientID, n int, err error,
+				) {
+					results <- core.AsyncResult{Error: wantErr}
+					return 1, 0, 10, nil
+				},
+			)
+			sender = sndr.New(group, sndr.WithCache[any](sndr.NewLRUStore(10),
+				sndr.WithNegativeCaching(time.Minute, wantErr)))
+			cmd   = cacheableCmd{cmocks.NewCmd(), "key"}
+			mocks = []*mok.Mock{group.Mock}
+		)
+		for range 2 {
+			_, err := sender.Send(context.Background(), cmd)
+			asserterror.EqualError(err, wantErr, t)
+		}
+		asserterror.EqualDeep(mok.CheckCalls(mocks), mok.EmptyInfomap, t)
+	})
+
+	t.Run("Should not cache Commands that are not Cacheable", func(t *testing.T) {
+		var (
+			group = mocks.NewClientGroup().RegisterSend(
+				sendResult(cmocks.NewResult()),
+			).RegisterSend(sendResult(cmocks.NewResult()))
+			sender = sndr.New(group, sndr.WithCache[any](sndr.NewLRUStore(10)))
+			mocks  = []*mok.Mock{group.Mock}
+		)
+		for range 2 {
+			_, err := sender.Send(context.Background(), cmocks.NewCmd())
+			asserterror.EqualError(err, nil, t)
+		}
+		asserterror.EqualDeep(mok.CheckCalls(mocks), mok.EmptyInfomap, t)
+	})
+
+	t.Run("Should not cache if the store is nil", func(t *testing.T) {
+		var (
+			group = mocks.NewClientGroup().RegisterSend(
+				sendResult(cmocks.NewResult()),
+			).RegisterSend(sendResult(cmocks.NewResult()))
+			sender = sndr.New(group, sndr.WithCache[any](nil))
+			cmd    = cacheableCmd{cmocks.NewCmd(), "key"}
+			mocks  = []*mok.Mock{group.Mock}
+		)
+		for range 2 {
+			_, err := sender.Send(context.Background(), cmd)
+			asserterror.EqualError(err, nil, t)
+		}
+		asserterror.EqualDeep(mok.CheckCalls(mocks), mok.EmptyInfomap, t)
+	})
+}
+
+func TestLRUStore(t *testing.T) {
+	store := sndr.NewLRUStore(2)
+	store.Set("a", sndr.CacheEntry{})
+	store.Set("b", sndr.CacheEntry{})
+	store.Get("a")
+	store.Set("c", sndr.CacheEntry{})
+	_, ok := store.Get("b")
+	asserterror.Equal(ok, false, t)
+	_, ok = store.Get("a")
+	asserterror.Equal(ok, true, t)
+	asserterror.Equal(store.Len(), 2, t)
+	store.Delete("a")
+	asserterror.Equal(store.Len(), 1, t)
+}
+
+// cacheHooks counts stale cache hits.
+type cacheHooks struct {
+	hks.NoopHooks[any]
+	stale atomic.Int64
+}
+
+func (h *cacheHooks) New() hks.Hooks[any] { return h }
+
+func (h *cacheHooks) OnCacheHit(ctx context.Context, cmd core.Cmd[any],
+	hit hks.CacheHit,
+) {
+	if hit.Stale {
+		h.stale.Add(1)
+	}
+}
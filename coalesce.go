@@ -0,0 +1,84 @@
+package sender
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/cmd-stream/core-go"
+)
+
+// Coalescable is implemented by Commands that can share a single send with
+// identical Commands already in flight, see WithCoalescing. Commands with the
+// same coalesce key are considered identical.
+type Coalescable interface {
+	CoalesceKey() string
+}
+
+func newCoalescer() *coalescer {
+	return &coalescer{calls: map[string]*coalescedCall{}}
+}
+
+// coalescer keeps track of the shared sends in flight.
+type coalescer struct {
+	mu    sync.Mutex
+	calls map[string]*coalescedCall
+}
+
+// coalescedCall is a shared send, waiters field is guarded by the
+// coalescer.mu.
+type coalescedCall struct {
+	waiters int
+	cancel  context.CancelCauseFunc
+	done    chan struct{}
+	result  core.Result
+	err     error
+}
+
+// sendCoalesced joins the shared send of the identical Command in flight, or
+// starts a new one. The shared send does not depend on the ctx of any waiter,
+// it is forgotten (with ErrCanceled) only when all waiters have gone away.
+func (s Sender[T]) sendCoalesced(ctx context.Context, cmd core.Cmd[T],
+	key string,
+	deadline time.Time,
+) (result core.Result, err error) {
+	c := s.coalescer
+	c.mu.Lock()
+	call, pst := c.calls[key]
+	if !pst {
+		cctx, cancel := context.WithCancelCause(context.WithoutCancel(ctx))
+		call = &coalescedCall{cancel: cancel, done: make(chan struct{})}
+		c.calls[key] = call
+		go func() {
+			call.result, call.err = s.sendDirect(cctx, cmd, deadline)
+			c.mu.Lock()
+			if c.calls[key] == call {
+				delete(c.calls, key)
+			}
+			c.mu.Unlock()
+			cancel(nil)
+			close(call.done)
+		}()
+	}
+	call.waiters++
+	c.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.result, call.err
+	case <-ctx.Done():
+		c.mu.Lock()
+		call.waiters--
+		if call.waiters == 0 {
+			if c.calls[key] == call {
+				delete(c.calls, key)
+			}
+			call.cancel(ErrCanceled)
+		}
+		c.mu.Unlock()
+		if context.Cause(ctx) == ErrCanceled {
+			return nil, ErrCanceled
+		}
+		return nil, ErrTimeout
+	}
+}
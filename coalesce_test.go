@@ -0,0 +1,106 @@
+package sender_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	grp "github.com/cmd-stream/cmd-stream-go/group"
+	"github.com/cmd-stream/core-go"
+	sndr "github.com/cmd-stream/sender-go"
+	"github.com/cmd-stream/sender-go/test/mocks"
+	cmocks "github.com/cmd-stream/testkit-go/mocks/core"
+	asserterror "github.com/ymz-ncnk/assert/error"
+	"github.com/ymz-ncnk/mok"
+)
+
+type coalescableCmd struct {
+	core.Cmd[any]
+	key string
+}
+
+func (c coalescableCmd) CoalesceKey() string { return c.key }
+
+func TestCoalescing(t *testing.T) {
+	t.Run("Identical Commands should share a single send", func(t *testing.T) {
+		var (
+			wantResult = cmocks.NewResult()
+			sent       = make(chan chan<- core.AsyncResult, 1)
+			group      = mocks.NewClientGroup().RegisterSend(
+				func(cmd core.Cmd[any], results chan<- core.AsyncResult) (
+					seq core.Seq, clientID grp.ClientID, n int, err error,
+				) {
+					sent <- results
+					return 1, 0, 10, nil
+				},
+			)
+			sender  = sndr.New(group, sndr.WithCoalescing[any]())
+			cmd     = coalescableCmd{cmocks.NewCmd(), "key"}
+			mocks   = []*mok.Mock{group.Mock}
+			results = make(chan core.Result, 3)
+		)
+		send := func() {
+			result, err := sender.Send(context.Background(), cmd)
+			asserterror.EqualError(err, nil, t)
+			results <- result
+		}
+		go send()
+		ch := <-sent
+		go send()
+		go send()
+		time.Sleep(100 * time.Millisecond)
+		ch <- core.AsyncResult{Result: wantResult}
+		for range 3 {
+			asserterror.EqualDeep(<-results, core.Result(wantResult), t)
+		}
+
+		asserterror.EqualDeep(mok.CheckCalls(mocks), mok.EmptyInfomap, t)
+	})
+
+	t.Run("Should forget the shared Command when all waiters have gone away",
+		func(t *testing.T) {
+			var (
+				sent      = make(chan struct{}, 1)
+				forgotten = make(chan struct{})
+				group     = mocks.NewClientGroup().RegisterSend(
+					func(cmd core.Cmd[any], results chan<- core.AsyncResult) (
+						seq core.Seq, clientID grp.ClientID, n int, err error,
+					) {
+						sent <- struct{}{}
+						return 1, 0, 10, nil
+					},
+				).RegisterForget(
+					func(seq core.Seq, clientID grp.ClientID) { close(forgotten) },
+				)
+				sender        = sndr.New(group, sndr.WithCoalescing[any]())
+				cmd           = coalescableCmd{cmocks.NewCmd(), "key"}
+				mocks         = []*mok.Mock{group.Mock}
+				ctx1, cancel1 = context.WithCancel(context.Background())
+				ctx2, cancel2 = context.WithCancel(context.Background())
+				errs          = make(chan error, 2)
+			)
+			go func() {
+				_, err := sender.Send(ctx1, cmd)
+				errs <- err
+			}()
+			<-sent
+			go func() {
+				_, err := sender.Send(ctx2, cmd)
+				errs <- err
+			}()
+			time.Sleep(100 * time.Millisecond)
+
+			cancel1()
+			asserterror.EqualError(<-errs, sndr.ErrTimeout, t)
+			select {
+			case <-forgotten:
+				t.Fatal("the Command is forgotten while a waiter is left")
+			case <-time.After(100 * time.Millisecond):
+			}
+			cancel2()
+			asserterror.EqualError(<-errs, sndr.ErrTimeout, t)
+			<-forgotten
+
+			asserterror.EqualDeep(mok.CheckCalls(mocks), mok.EmptyInfomap, t)
+		})
+}
@@ -0,0 +1,87 @@
+package sender_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	grp "github.com/cmd-stream/cmd-stream-go/group"
+	"github.com/cmd-stream/core-go"
+	sndr "github.com/cmd-stream/sender-go"
+	"github.com/cmd-stream/sender-go/test/mocks"
+	cmocks "github.com/cmd-stream/testkit-go/mocks/core"
+	asserterror "github.com/ymz-ncnk/assert/error"
+	"github.com/ymz-ncnk/mok"
+)
+
+func TestContextDeadline(t *testing.T) {
+	t.Run("Should send with the ctx deadline minus the margin",
+		func(t *testing.T) {
+			var (
+				margin       = 100 * time.Millisecond
+				ctxDeadline  = time.Now().Add(time.Hour)
+				ctx, cancel  = context.WithDeadline(context.Background(), ctxDeadline)
+				wantDeadline = ctxDeadline.Add(-margin)
+				wantResult   = cmocks.NewResult()
+				group        = mocks.NewClientGroup().RegisterSendWithDeadline(
+					func(cmd core.Cmd[any], results chan<- core.AsyncResult,
+						deadline time.Time,
+					) (seq core.Seq, clientID grp.ClientID, n int, err error) {
+						asserterror.Equal(deadline, wantDeadline, t)
+						results <- core.AsyncResult{Result: wantResult}
+						return 1, 0, 10, nil
+					},
+				)
+				sender = sndr.New(group, sndr.WithContextDeadline[any](margin))
+				mocks  = []*mok.Mock{group.Mock}
+			)
+			defer cancel()
+			result, err := sender.Send(ctx, cmocks.NewCmd())
+			asserterror.EqualError(err, nil, t)
+			asserterror.EqualDeep(result, core.Result(wantResult), t)
+
+			asserterror.EqualDeep(mok.CheckCalls(mocks), mok.EmptyInfomap, t)
+		})
+
+	t.Run("Explicit deadline should take precedence", func(t *testing.T) {
+		var (
+			ctx, cancel  = context.WithTimeout(context.Background(), time.Hour)
+			wantDeadline = time.Now().Add(time.Minute)
+			group        = mocks.NewClientGroup().RegisterSendWithDeadline(
+				func(cmd core.Cmd[any], results chan<- core.AsyncResult,
+					deadline time.Time,
+				) (seq core.Seq, clientID grp.ClientID, n int, err error) {
+					asserterror.Equal(deadline, wantDeadline, t)
+					results <- core.AsyncResult{Result: cmocks.NewResult()}
+					return 1, 0, 10, nil
+				},
+			)
+			sender = sndr.New(group, sndr.WithContextDeadline[any](0))
+			mocks  = []*mok.Mock{group.Mock}
+		)
+		defer cancel()
+		_, err := sender.SendWithDeadline(ctx, cmocks.NewCmd(), wantDeadline)
+		asserterror.EqualError(err, nil, t)
+
+		asserterror.EqualDeep(mok.CheckCalls(mocks), mok.EmptyInfomap, t)
+	})
+
+	t.Run("Should send without deadline if the ctx has none", func(t *testing.T) {
+		var (
+			group = mocks.NewClientGroup().RegisterSend(
+				func(cmd core.Cmd[any], results chan<- core.AsyncResult) (
+					seq core.Seq, clientID grp.ClientID, n int, err error,
+				) {
+					results <- core.AsyncResult{Result: cmocks.NewResult()}
+					return 1, 0, 10, nil
+				},
+			)
+			sender = sndr.New(group, sndr.WithContextDeadline[any](time.Second))
+			mocks  = []*mok.Mock{group.Mock}
+		)
+		_, err := sender.Send(context.Background(), cmocks.NewCmd())
+		asserterror.EqualError(err, nil, t)
+
+		asserterror.EqualDeep(mok.CheckCalls(mocks), mok.EmptyInfomap, t)
+	})
+}
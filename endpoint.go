@@ -0,0 +1,111 @@
+package sender
+
+import (
+	"sync"
+	"time"
+
+	grp "github.com/cmd-stream/cmd-stream-go/group"
+	"github.com/cmd-stream/core-go"
+)
+
+// endpoint tracks the health of a single server address. It becomes unhealthy
+// after the specified number of consecutive send failures, or once one of its
+// clients is done, and is retried once the cooldown has passed.
+type endpoint struct {
+	maxFailures int
+	cooldown    time.Duration
+
+	mu        sync.Mutex
+	failures  int
+	downUntil time.Time
+}
+
+func (e *endpoint) healthy() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.failures < e.maxFailures || !time.Now().Before(e.downUntil)
+}
+
+func (e *endpoint) report(err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if err == nil {
+		e.failures = 0
+		return
+	}
+	e.failures++
+	if e.failures >= e.maxFailures {
+		e.downUntil = time.Now().Add(e.cooldown)
+	}
+}
+
+// down makes the endpoint unhealthy for the cooldown.
+func (e *endpoint) down() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.failures = max(e.failures, e.maxFailures)
+	e.downUntil = time.Now().Add(e.cooldown)
+}
+
+// newEndpointClient creates a new endpointClient. Once the client is done, its
+// endpoint is marked as down.
+func newEndpointClient[T any](client grp.Client[T],
+	e *endpoint,
+) endpointClient[T] {
+	go func() {
+		<-client.Done()
+		e.down()
+	}()
+	return endpointClient[T]{client, e}
+}
+
+// endpointClient reports the outcome of each send to the endpoint of the
+// client.
+type endpointClient[T any] struct {
+	grp.Client[T]
+	endpoint *endpoint
+}
+
+func (c endpointClient[T]) Send(cmd core.Cmd[T],
+	results chan<- core.AsyncResult,
+) (seq core.Seq, n int, err error) {
+	seq, n, err = c.Client.Send(cmd, results)
+	c.endpoint.report(err)
+	return
+}
+
+func (c endpointClient[T]) SendWithDeadline(cmd core.Cmd[T],
+	results chan<- core.AsyncResult,
+	deadline time.Time,
+) (seq core.Seq, n int, err error) {
+	seq, n, err = c.Client.SendWithDeadline(cmd, results, deadline)
+	c.endpoint.report(err)
+	return
+}
+
+func (c endpointClient[T]) available() bool {
+	select {
+	case <-c.Done():
+		return false
+	default:
+		return c.endpoint.healthy()
+	}
+}
+
+// endpointStrategy skips clients of unhealthy endpoints and closed clients,
+// otherwise it follows the wrapped strategy. If no client is available, the
+// one chosen by the wrapped strategy is returned.
+type endpointStrategy[T any] struct {
+	grp.DispatchStrategy[grp.Client[T]]
+}
+
+func (s endpointStrategy[T]) Next() (client grp.Client[T], index int64) {
+	client, index = s.DispatchStrategy.Next()
+	for range len(s.Slice()) - 1 {
+		if c, ok := client.(endpointClient[T]); !ok || c.available() {
+			return
+		}
+		client, index = s.DispatchStrategy.Next()
+	}
+	return
+}
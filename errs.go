@@ -1,7 +1,186 @@
 package sender
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/cmd-stream/core-go"
+)
 
 // ErrTimeout is returned when a command is sent but no result is received
 // within the expected time.
 var ErrTimeout = errors.New("timeout")
+
+// ErrCanceled is passed to hooks.OnTimeout when the caller stops waiting for
+// the Results before the last one is received (for example, when the
+// ResultHandler returns an error).
+var ErrCanceled = errors.New("canceled")
+
+// ErrClosed is returned by the send methods of a closed Sender, see Close and
+// Shutdown.
+var ErrClosed = errors.New("sender closed")
+
+// ErrNoAddrs is returned by MakeMulti when no addresses are specified.
+var ErrNoAddrs = errors.New("no addresses")
+
+// ErrNoDialer is returned by Make, MakeMulti and MakeResolved when the dialer
+// is nil, see WithDialer and WithDialContext.
+var ErrNoDialer = errors.New("no dialer")
+
+// ErrNoClients happens when the resolver currently provides no addresses, or
+// clients for none of them could be created.
+var ErrNoClients = errors.New("no clients")
+
+// NewDialError creates a new DialError.
+func NewDialError(network, addr string, attempt int, cause error) error {
+	return &DialError{Network: network, Addr: addr, Attempt: attempt,
+		Err: cause}
+}
+
+// DialError is returned when a connection to the server cannot be
+// established. Attempts to connect to the same address are counted, including
+// reconnects, starting from 1.
+type DialError struct {
+	Network string
+	Addr    string
+	Attempt int
+	Err     error
+}
+
+func (e *DialError) Error() string {
+	return fmt.Sprintf("failed to dial %v %v (attempt %v): %v", e.Network,
+		e.Addr, e.Attempt, e.Err)
+}
+
+func (e *DialError) Unwrap() error {
+	return e.Err
+}
+
+// ErrBroadcastUnsupported is returned by Broadcast when the client group does
+// not implement BroadcastGroup.
+var ErrBroadcastUnsupported = errors.New("client group does not support broadcast")
+
+// ErrPinningUnsupported is returned by SendBatch with WithPinning when the
+// client group does not implement BroadcastGroup.
+var ErrPinningUnsupported = errors.New("client group does not support pinning")
+
+// NewBatchError creates a new BatchError.
+func NewBatchError(index int, cause error) error {
+	return &BatchError{Index: index, Err: cause}
+}
+
+// BatchError is returned by SendBatch for a failed Command, Index is its
+// position in the batch.
+type BatchError struct {
+	Index int
+	Err   error
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("command %v of the batch failed: %v", e.Index, e.Err)
+}
+
+func (e *BatchError) Unwrap() error {
+	return e.Err
+}
+
+// NewQuorumError creates a new QuorumError.
+func NewQuorumError(succeeded, quorum int, cause error) error {
+	return &QuorumError{Succeeded: succeeded, Quorum: quorum, Err: cause}
+}
+
+// QuorumError is returned by Broadcast when fewer clients than required
+// succeed. Err contains errors of the failed clients.
+type QuorumError struct {
+	Succeeded int
+	Quorum    int
+	Err       error
+}
+
+func (e *QuorumError) Error() string {
+	return fmt.Sprintf("quorum not reached, %v of %v succeeded: %v",
+		e.Succeeded, e.Quorum, e.Err)
+}
+
+func (e *QuorumError) Unwrap() error {
+	return e.Err
+}
+
+// ErrHedgeLost is passed to hooks.OnTimeout of the hedged sends that lost to
+// another send of the same Command.
+var ErrHedgeLost = errors.New("hedge lost")
+
+// ErrUnexpectedResultType is wrapped by UnexpectedResultTypeError.
+var ErrUnexpectedResultType = errors.New("unexpected result type")
+
+// NewUnexpectedResultTypeError creates a new UnexpectedResultTypeError.
+func NewUnexpectedResultTypeError(result core.Result, want reflect.Type) error {
+	return &UnexpectedResultTypeError{Result: result, Want: want}
+}
+
+// UnexpectedResultTypeError is returned by the typed send functions (such as
+// SendAs) when the received Result has an unexpected type.
+type UnexpectedResultTypeError struct {
+	Result core.Result
+	Want   reflect.Type
+}
+
+func (e *UnexpectedResultTypeError) Error() string {
+	return fmt.Sprintf("%v %T, want %v", ErrUnexpectedResultType, e.Result,
+		e.Want)
+}
+
+func (e *UnexpectedResultTypeError) Unwrap() error {
+	return ErrUnexpectedResultType
+}
+
+// FailureSource identifies where the failure of a multi-result Command came
+// from.
+type FailureSource int
+
+const (
+	// SourceServer means the error was received along with a Result.
+	SourceServer FailureSource = iota + 1
+	// SourceHandler means the error was returned by the ResultHandler.
+	SourceHandler
+	// SourceTimeout means the Results were not received in time.
+	SourceTimeout
+)
+
+func (s FailureSource) String() string {
+	switch s {
+	case SourceServer:
+		return "server"
+	case SourceHandler:
+		return "handler"
+	case SourceTimeout:
+		return "timeout"
+	default:
+		return fmt.Sprintf("FailureSource(%d)", int(s))
+	}
+}
+
+// MultiResultError is returned by SendMulti and SendMultiWithDeadline when
+// receiving of the Results fails.
+type MultiResultError struct {
+	// Received is the number of received Results.
+	Received int
+	// Expected is the resultsCount passed to SendMulti.
+	Expected int
+	// Source tells where the error came from.
+	Source FailureSource
+	// LastSeq is the sequence number of the last received Result (as in
+	// hooks.ReceivedResult), or 0 if none was received.
+	LastSeq core.Seq
+	Err     error
+}
+
+func (e *MultiResultError) Error() string {
+	return fmt.Sprintf("%v error after %v of %v results (last seq %v): %v",
+		e.Source, e.Received, e.Expected, e.LastSeq, e.Err)
+}
+
+func (e *MultiResultError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,181 @@
+package sender
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync/atomic"
+	"time"
+
+	"github.com/cmd-stream/core-go"
+)
+
+// SendAsync sends a Command to the server and returns a Future for its
+// Result without waiting for it.
+//
+// The Command is sent before SendAsync returns, so Commands sent one after
+// another are written in the same order. Hooks are called exactly once per
+// Command, even if the Future is never waited for. The Result is waited for
+// until the ctx is done (in which case the Future completes with ErrTimeout)
+// or the Future is canceled.
+//
+// The Command is sent once, directly to the client group: retries (WithRetry),
+// hedging (WithHedging), the cache (WithCache) and coalescing
+// (WithCoalescing) are not applied to asynchronous sends.
+func (s Sender[T]) SendAsync(ctx context.Context, cmd core.Cmd[T]) *Future {
+	return s.sendAsync(ctx, cmd, time.Time{})
+}
+
+// SendAsyncWithDeadline is like SendAsync, but sends the Command with the
+// specified deadline.
+func (s Sender[T]) SendAsyncWithDeadline(ctx context.Context, cmd core.Cmd[T],
+	deadline time.Time,
+) *Future {
+	return s.sendAsync(ctx, cmd, deadline)
+}
+
+func (s Sender[T]) sendAsync(ctx context.Context, cmd core.Cmd[T],
+	deadline time.Time,
+) *Future {
+	var (
+		results      = make(chan core.AsyncResult, 1)
+		cctx, cancel = context.WithCancelCause(ctx)
+		future       = &Future{done: make(chan struct{}), cancel: cancel}
+	)
+	if !s.tracker.acquire() {
+		future.complete(nil, ErrClosed)
+		return future
+	}
+	f, err := s.dispatch(cctx, cmd, results, deadline)
+	if err != nil {
+		s.tracker.release()
+		future.complete(nil, err)
+		return future
+	}
+	go func() {
+		defer s.tracker.release()
+		future.complete(s.receiveAsync(f, future, results))
+	}()
+	return future
+}
+
+// receiveAsync is like receive, but a Result that is ready when the Future is
+// canceled is not forgotten, the Future completes with ErrCanceled instead.
+func (s Sender[T]) receiveAsync(f flight[T], future *Future,
+	results <-chan core.AsyncResult,
+) (result core.Result, err error) {
+	select {
+	case <-f.ctx.Done():
+		select {
+		case asyncResult := <-results:
+			result, err = s.onResult(f, asyncResult)
+		default:
+			return nil, s.abandon(f)
+		}
+	case asyncResult := <-results:
+		result, err = s.onResult(f, asyncResult)
+	}
+	if !future.settle() {
+		return nil, ErrCanceled
+	}
+	return
+}
+
+const (
+	futurePending int32 = iota
+	futureSettled
+	futureCanceled
+)
+
+// Future represents the Result of a Command sent with SendAsync.
+type Future struct {
+	done   chan struct{}
+	state  atomic.Int32
+	result core.Result
+	err    error
+	cancel context.CancelCauseFunc
+}
+
+// Done returns a channel that is closed when the Future completes.
+func (f *Future) Done() <-chan struct{} {
+	return f.done
+}
+
+// Wait waits (using the ctx) for the Future to complete and returns its
+// Result. If the ctx is done first, Wait returns ErrTimeout, but the Future
+// keeps waiting for the Result.
+func (f *Future) Wait(ctx context.Context) (result core.Result, err error) {
+	select {
+	case <-ctx.Done():
+		return nil, ErrTimeout
+	case <-f.done:
+		return f.result, f.err
+	}
+}
+
+// Cancel stops waiting for the Result, the Command is forgotten by the
+// client group and the Future completes with ErrCanceled. It has no effect if
+// the Future is already completed.
+func (f *Future) Cancel() {
+	if f.state.CompareAndSwap(futurePending, futureCanceled) {
+		f.cancel(ErrCanceled)
+	}
+}
+
+// settle is called once the Result is received, it returns false if the
+// Future was canceled before.
+func (f *Future) settle() bool {
+	return f.state.CompareAndSwap(futurePending, futureSettled)
+}
+
+func (f *Future) complete(result core.Result, err error) {
+	f.state.CompareAndSwap(futurePending, futureSettled)
+	f.result = result
+	f.err = err
+	f.cancel(nil)
+	close(f.done)
+}
+
+// WaitAll waits (using the ctx) for all Futures to complete. It returns their
+// Results in the same order, and errors, if any, joined together.
+func WaitAll(ctx context.Context, futures ...*Future) (
+	results []core.Result, err error,
+) {
+	results = make([]core.Result, len(futures))
+	var errs []error
+	for i := range futures {
+		var ferr error
+		results[i], ferr = futures[i].Wait(ctx)
+		if ferr != nil {
+			errs = append(errs, ferr)
+		}
+	}
+	return results, errors.Join(errs...)
+}
+
+// WaitAny waits (using the ctx) for the first of the Futures to complete and
+// returns its index and Result. If the ctx is done first, index == -1 and
+// err == ErrTimeout. If there are no Futures, index == -1 and err == nil.
+func WaitAny(ctx context.Context, futures ...*Future) (index int,
+	result core.Result, err error,
+) {
+	if len(futures) == 0 {
+		return -1, nil, nil
+	}
+	cases := make([]reflect.SelectCase, len(futures)+1)
+	for i := range futures {
+		cases[i] = reflect.SelectCase{
+			Dir:  reflect.SelectRecv,
+			Chan: reflect.ValueOf(futures[i].done),
+		}
+	}
+	cases[len(futures)] = reflect.SelectCase{
+		Dir:  reflect.SelectRecv,
+		Chan: reflect.ValueOf(ctx.Done()),
+	}
+	index, _, _ = reflect.Select(cases)
+	if index == len(futures) {
+		return -1, nil, ErrTimeout
+	}
+	return index, futures[index].result, futures[index].err
+}
@@ -0,0 +1,236 @@
+package sender_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	grp "github.com/cmd-stream/cmd-stream-go/group"
+	"github.com/cmd-stream/core-go"
+	sndr "github.com/cmd-stream/sender-go"
+	hks "github.com/cmd-stream/sender-go/hooks"
+	"github.com/cmd-stream/sender-go/test/mocks"
+	cmocks "github.com/cmd-stream/testkit-go/mocks/core"
+	asserterror "github.com/ymz-ncnk/assert/error"
+	"github.com/ymz-ncnk/mok"
+)
+
+func TestFuture(t *testing.T) {
+	t.Run("Should complete with the Result", func(t *testing.T) {
+		var (
+			wantResult = cmocks.NewResult()
+			group      = mocks.NewClientGroup().RegisterSend(
+				func(cmd core.Cmd[any], results chan<- core.AsyncResult) (
+					seq core.Seq, clientID grp.ClientID, n int, err error,
+				) {
+					results <- core.AsyncResult{BytesRead: 2, Result: wantResult}
+					return 1, 0, 10, nil
+				},
+			)
+			hooks = mocks.NewHooks[any]().RegisterBeforeSend(
+				func(ctx context.Context, cmd core.Cmd[any]) (context.Context, error) {
+					return ctx, nil
+				},
+			).RegisterOnResult(
+				func(ctx context.Context, sentCmd hks.SentCmd[any],
+					recvResult hks.ReceivedResult, err error,
+				) {
+					asserterror.Equal(recvResult.Size, 2, t)
+				},
+			)
+			factory = mocks.NewHooksFactory[any]().RegisterNew(
+				func() hks.Hooks[any] { return hooks },
+			)
+			sender = sndr.New(group, sndr.WithHooksFactory[any](factory))
+			mocks  = []*mok.Mock{group.Mock, hooks.Mock, factory.Mock}
+		)
+		future := sender.SendAsync(context.Background(), cmocks.NewCmd())
+		<-future.Done()
+		result, err := future.Wait(context.Background())
+		asserterror.EqualError(err, nil, t)
+		asserterror.EqualDeep(result, core.Result(wantResult), t)
+
+		asserterror.EqualDeep(mok.CheckCalls(mocks), mok.EmptyInfomap, t)
+	})
+
+	t.Run("Cancel should forget the Command", func(t *testing.T) {
+		var (
+			wantSeq      core.Seq     = 2
+			wantClientID grp.ClientID = 1
+			group                     = mocks.NewClientGroup().RegisterSend(
+				func(cmd core.Cmd[any], results chan<- core.AsyncResult) (
+					seq core.Seq, clientID grp.ClientID, n int, err error,
+				) {
+					return wantSeq, wantClientID, 10, nil
+				},
+			).RegisterForget(
+				func(seq core.Seq, clientID grp.ClientID) {
+					asserterror.Equal(seq, wantSeq, t)
+					asserterror.Equal(clientID, wantClientID, t)
+				},
+			)
+			hooks = mocks.NewHooks[any]().RegisterBeforeSend(
+				func(ctx context.Context, cmd core.Cmd[any]) (context.Context, error) {
+					return ctx, nil
+				},
+			).RegisterOnTimeout(
+				func(ctx context.Context, sentCmd hks.SentCmd[any], err error) {
+					asserterror.EqualError(err, sndr.ErrCanceled, t)
+				},
+			)
+			factory = mocks.NewHooksFactory[any]().RegisterNew(
+				func() hks.Hooks[any] { return hooks },
+			)
+			sender = sndr.New(group, sndr.WithHooksFactory[any](factory))
+			mocks  = []*mok.Mock{group.Mock, hooks.Mock, factory.Mock}
+		)
+		future := sender.SendAsync(context.Background(), cmocks.NewCmd())
+		future.Cancel()
+		_, err := future.Wait(context.Background())
+		asserterror.EqualError(err, sndr.ErrCanceled, t)
+
+		asserterror.EqualDeep(mok.CheckCalls(mocks), mok.EmptyInfomap, t)
+	})
+
+	t.Run("Cancel should not forget a received Command", func(t *testing.T) {
+		var (
+			canceled = make(chan struct{})
+			group    = mocks.NewClientGroup().RegisterSend(
+				func(cmd core.Cmd[any], results chan<- core.AsyncResult) (
+					seq core.Seq, clientID grp.ClientID, n int, err error,
+				) {
+					results <- core.AsyncResult{Result: cmocks.NewResult()}
+					return 1, 0, 10, nil
+				},
+			)
+			hooks = mocks.NewHooks[any]().RegisterBeforeSend(
+				func(ctx context.Context, cmd core.Cmd[any]) (context.Context, error) {
+					return ctx, nil
+				},
+			).RegisterOnResult(
+				func(ctx context.Context, sentCmd hks.SentCmd[any],
+					recvResult hks.ReceivedResult, err error,
+				) {
+					<-canceled
+				},
+			)
+			factory = mocks.NewHooksFactory[any]().RegisterNew(
+				func() hks.Hooks[any] { return hooks },
+			)
+			sender = sndr.New(group, sndr.WithHooksFactory[any](factory))
+			mocks  = []*mok.Mock{group.Mock, hooks.Mock, factory.Mock}
+		)
+		future := sender.SendAsync(context.Background(), cmocks.NewCmd())
+		future.Cancel()
+		close(canceled)
+		_, err := future.Wait(context.Background())
+		asserterror.EqualError(err, sndr.ErrCanceled, t)
+
+		asserterror.EqualDeep(mok.CheckCalls(mocks), mok.EmptyInfomap, t)
+	})
+
+	t.Run("Should prefer a ready Result to the done ctx", func(t *testing.T) {
+		var (
+			wantResult  = cmocks.NewResult()
+			ctx, cancel = context.WithCancel(context.Background())
+			group       = mocks.NewClientGroup().RegisterSend(
+				func(cmd core.Cmd[any], results chan<- core.AsyncResult) (
+					seq core.Seq, clientID grp.ClientID, n int, err error,
+				) {
+					results <- core.AsyncResult{Result: wantResult}
+					return 1, 0, 10, nil
+				},
+			)
+			sender = sndr.New[any](group)
+			mocks  = []*mok.Mock{group.Mock}
+		)
+		cancel()
+		future := sender.SendAsync(ctx, cmocks.NewCmd())
+		result, err := future.Wait(context.Background())
+		asserterror.EqualError(err, nil, t)
+		asserterror.EqualDeep(result, core.Result(wantResult), t)
+
+		asserterror.EqualDeep(mok.CheckCalls(mocks), mok.EmptyInfomap, t)
+	})
+
+	t.Run("Should complete with ClientGroup.Send error", func(t *testing.T) {
+		var (
+			wantErr = errors.New("ClientGroup.Send error")
+			group   = mocks.NewClientGroup().RegisterSend(
+				func(cmd core.Cmd[any], results chan<- core.AsyncResult) (
+					seq core.Seq, clientID grp.ClientID, n int, err error,
+				) {
+					return 0, 0, 0, wantErr
+				},
+			)
+			sender = sndr.New[any](group)
+		)
+		future := sender.SendAsync(context.Background(), cmocks.NewCmd())
+		select {
+		case <-future.Done():
+		default:
+			t.Fatal("future is not completed")
+		}
+		_, err := future.Wait(context.Background())
+		asserterror.EqualError(err, wantErr, t)
+	})
+
+	t.Run("Wait should return ErrTimeout if the ctx is done", func(t *testing.T) {
+		var (
+			group = mocks.NewClientGroup().RegisterSend(
+				func(cmd core.Cmd[any], results chan<- core.AsyncResult) (
+					seq core.Seq, clientID grp.ClientID, n int, err error,
+				) {
+					return 1, 0, 10, nil
+				},
+			).RegisterForget(func(seq core.Seq, clientID grp.ClientID) {})
+			sender      = sndr.New[any](group)
+			ctx, cancel = context.WithCancel(context.Background())
+		)
+		future := sender.SendAsync(context.Background(), cmocks.NewCmd())
+		cancel()
+		_, err := future.Wait(ctx)
+		asserterror.EqualError(err, sndr.ErrTimeout, t)
+		future.Cancel()
+		<-future.Done()
+	})
+
+	t.Run("WaitAll and WaitAny", func(t *testing.T) {
+		var (
+			wantErr    = errors.New("ClientGroup.Send error")
+			wantResult = cmocks.NewResult()
+			group      = mocks.NewClientGroup().RegisterSend(
+				func(cmd core.Cmd[any], results chan<- core.AsyncResult) (
+					seq core.Seq, clientID grp.ClientID, n int, err error,
+				) {
+					return 0, 0, 0, wantErr
+				},
+			).RegisterSend(
+				func(cmd core.Cmd[any], results chan<- core.AsyncResult) (
+					seq core.Seq, clientID grp.ClientID, n int, err error,
+				) {
+					results <- core.AsyncResult{Result: wantResult}
+					return 1, 0, 10, nil
+				},
+			)
+			sender  = sndr.New[any](group)
+			futures = []*sndr.Future{
+				sender.SendAsync(context.Background(), cmocks.NewCmd()),
+				sender.SendAsync(context.Background(), cmocks.NewCmd()),
+			}
+		)
+		index, _, err := sndr.WaitAny(context.Background(), futures[0])
+		asserterror.Equal(index, 0, t)
+		asserterror.EqualError(err, wantErr, t)
+
+		results, err := sndr.WaitAll(context.Background(), futures...)
+		asserterror.EqualDeep(results, []core.Result{nil, wantResult}, t)
+		asserterror.Equal(errors.Is(err, wantErr), true, t)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		index, _, err = sndr.WaitAny(ctx, &sndr.Future{})
+		asserterror.Equal(index, -1, t)
+		asserterror.EqualError(err, sndr.ErrTimeout, t)
+	})
+}
@@ -6,6 +6,7 @@ require (
 	github.com/cmd-stream/cmd-stream-go v0.4.4
 	github.com/cmd-stream/core-go v0.0.0-20251102020427-f23e62426486
 	github.com/cmd-stream/testkit-go v0.0.0-20251102015907-0ae91640601a
+	github.com/cmd-stream/transport-go v0.0.0-20251102021115-2f2d348f4122
 	github.com/ymz-ncnk/assert v0.0.0-20250528151733-c41b2fca7933
 	github.com/ymz-ncnk/mok v0.2.1
 )
@@ -13,7 +14,6 @@ require (
 require (
 	github.com/cmd-stream/delegate-go v0.0.0-20251102020741-164e6005aadf // indirect
 	github.com/cmd-stream/handler-go v0.0.0-20251102020950-33189f2d8d28 // indirect
-	github.com/cmd-stream/transport-go v0.0.0-20251102021115-2f2d348f4122 // indirect
 	github.com/mus-format/common-go v0.0.0-20251026152644-9f5ac6728d8a // indirect
 	github.com/mus-format/mus-stream-go v0.7.2 // indirect
 	github.com/ymz-ncnk/jointwork-go v0.0.0-20240428103805-1ee224bde88a // indirect
@@ -21,3 +21,97 @@ type ClientGroup[T any] interface {
 	Err() (err error)
 	Close() (err error)
 }
+
+// BroadcastGroup is a ClientGroup that can send a Command through a specific
+// client, it is required by Broadcast. Client groups created by Make,
+// MakeMulti and MakeResolved implement it.
+type BroadcastGroup[T any] interface {
+	ClientGroup[T]
+	// ClientIDs returns the IDs of all clients Commands can be sent through.
+	ClientIDs() []grp.ClientID
+	SendTo(clientID grp.ClientID, cmd core.Cmd[T],
+		results chan<- core.AsyncResult,
+	) (seq core.Seq, n int, err error)
+	SendToWithDeadline(clientID grp.ClientID, cmd core.Cmd[T],
+		results chan<- core.AsyncResult,
+		deadline time.Time,
+	) (seq core.Seq, n int, err error)
+}
+
+// healthGroup is implemented by client groups that track the health of their
+// clients, such as the ones created by Make, MakeMulti and MakeResolved.
+type healthGroup interface {
+	available(clientID grp.ClientID) bool
+}
+
+// available reports whether the client of the group can be used. Clients of
+// groups that do not track health are always available.
+func available[T any](group ClientGroup[T], clientID grp.ClientID) bool {
+	if g, ok := group.(healthGroup); ok {
+		return g.available(clientID)
+	}
+	return true
+}
+
+// clientsGroup extends grp.ClientGroup to the BroadcastGroup, ClientIDs are
+// indexes of the clients. Sends through a specific client are reported to its
+// endpoint as well.
+type clientsGroup[T any] struct {
+	grp.ClientGroup[T]
+	clients []endpointClient[T]
+}
+
+func (g clientsGroup[T]) ClientIDs() (ids []grp.ClientID) {
+	ids = make([]grp.ClientID, len(g.clients))
+	for i := range ids {
+		ids[i] = grp.ClientID(i)
+	}
+	return
+}
+
+func (g clientsGroup[T]) available(clientID grp.ClientID) bool {
+	c, err := g.client(clientID)
+	return err == nil && c.available()
+}
+
+func (g clientsGroup[T]) SendTo(clientID grp.ClientID, cmd core.Cmd[T],
+	results chan<- core.AsyncResult,
+) (seq core.Seq, n int, err error) {
+	c, err := g.client(clientID)
+	if err != nil {
+		return
+	}
+	seq, n, err = c.Send(cmd, results)
+	if err != nil {
+		err = grp.NewGroupError(err)
+	}
+	return
+}
+
+func (g clientsGroup[T]) SendToWithDeadline(clientID grp.ClientID,
+	cmd core.Cmd[T],
+	results chan<- core.AsyncResult,
+	deadline time.Time,
+) (seq core.Seq, n int, err error) {
+	c, err := g.client(clientID)
+	if err != nil {
+		return
+	}
+	seq, n, err = c.SendWithDeadline(cmd, results, deadline)
+	if err != nil {
+		err = grp.NewGroupError(err)
+	}
+	return
+}
+
+// client returns the client with the specified ID, or ErrNoClients if there
+// is no such client.
+func (g clientsGroup[T]) client(clientID grp.ClientID) (c endpointClient[T],
+	err error,
+) {
+	if clientID < 0 || int(clientID) >= len(g.clients) {
+		err = ErrNoClients
+		return
+	}
+	return g.clients[clientID], nil
+}
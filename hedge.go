@@ -0,0 +1,281 @@
+package sender
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
+
+	grp "github.com/cmd-stream/cmd-stream-go/group"
+	"github.com/cmd-stream/core-go"
+	hks "github.com/cmd-stream/sender-go/hooks"
+)
+
+// HedgeDelay determines how long to wait for the Result before sending the
+// Command again.
+type HedgeDelay interface {
+	Delay() time.Duration
+	// Observe receives the latency of each successful hedged send.
+	Observe(latency time.Duration)
+}
+
+// NewFixedHedgeDelay creates a new FixedHedgeDelay.
+func NewFixedHedgeDelay(delay time.Duration) FixedHedgeDelay {
+	return FixedHedgeDelay{delay}
+}
+
+// FixedHedgeDelay always returns the same delay.
+type FixedHedgeDelay struct {
+	delay time.Duration
+}
+
+func (d FixedHedgeDelay) Delay() time.Duration {
+	return d.delay
+}
+
+func (d FixedHedgeDelay) Observe(latency time.Duration) {}
+
+// NewPercentileHedgeDelay creates a new PercentileHedgeDelay.
+//
+// The percentile is in the range (0, 1], for example, 0.95. The window is the
+// number of the last latencies used to estimate it. Until the window is
+// filled, the fallback delay is used.
+func NewPercentileHedgeDelay(percentile float64, window int,
+	fallback time.Duration,
+) *PercentileHedgeDelay {
+	return &PercentileHedgeDelay{
+		percentile: percentile,
+		fallback:   fallback,
+		latencies:  make([]time.Duration, 0, window),
+	}
+}
+
+// PercentileHedgeDelay returns the specified percentile of the observed
+// latencies, so that only the slowest Commands are hedged.
+//
+// PercentileHedgeDelay is safe for concurrent use.
+type PercentileHedgeDelay struct {
+	percentile float64
+	fallback   time.Duration
+
+	mu        sync.Mutex
+	latencies []time.Duration
+	next      int
+}
+
+func (d *PercentileHedgeDelay) Delay() time.Duration {
+	d.mu.Lock()
+	if len(d.latencies) < cap(d.latencies) || len(d.latencies) == 0 {
+		d.mu.Unlock()
+		return d.fallback
+	}
+	sorted := slices.Clone(d.latencies)
+	d.mu.Unlock()
+	slices.Sort(sorted)
+	i := int(d.percentile*float64(len(sorted))+0.5) - 1
+	return sorted[max(0, min(i, len(sorted)-1))]
+}
+
+func (d *PercentileHedgeDelay) Observe(latency time.Duration) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if cap(d.latencies) == 0 {
+		return
+	}
+	if len(d.latencies) < cap(d.latencies) {
+		d.latencies = append(d.latencies, latency)
+		return
+	}
+	d.latencies[d.next] = latency
+	d.next = (d.next + 1) % len(d.latencies)
+}
+
+// HedgeableCmd is a marker interface for Commands that are safe to send
+// several times (read-only and idempotent).
+type HedgeableCmd interface {
+	Hedgeable() bool
+}
+
+// HedgeableFn determines whether the Command can be hedged.
+type HedgeableFn func(cmd any) bool
+
+// IsHedgeableCmd returns true if the Command implements HedgeableCmd and
+// its Hedgeable method returns true.
+func IsHedgeableCmd(cmd any) bool {
+	c, ok := cmd.(HedgeableCmd)
+	return ok && c.Hedgeable()
+}
+
+type HedgeOptions struct {
+	Delay       HedgeDelay
+	MaxAttempts int
+	Hedgeable   HedgeableFn
+}
+
+type SetHedgeOption func(o *HedgeOptions)
+
+// WithHedgeMaxAttempts sets the maximum number of sends of the same Command,
+// including the first one.
+func WithHedgeMaxAttempts(n int) SetHedgeOption {
+	return func(o *HedgeOptions) { o.MaxAttempts = n }
+}
+
+// WithHedgeable sets a function that determines whether the Command can be
+// hedged.
+func WithHedgeable(fn HedgeableFn) SetHedgeOption {
+	return func(o *HedgeOptions) { o.Hedgeable = fn }
+}
+
+func ApplyHedge(ops []SetHedgeOption, o *HedgeOptions) {
+	for i := range ops {
+		if ops[i] != nil {
+			ops[i](o)
+		}
+	}
+}
+
+// sendHedged sends the Command, and then sends it again each time the hedge
+// delay expires without a Result, up to MaxAttempts (failed sends count as
+// attempts). The first successful Result wins, the remaining attempts are
+// forgotten with ErrHedgeLost.
+//
+// If the client group is a BroadcastGroup, each hedge is sent through an
+// available client not used by the previous ones, while there is such a
+// client.
+func (s Sender[T]) sendHedged(ctx context.Context, cmd core.Cmd[T],
+	deadline time.Time,
+) (result core.Result, err error) {
+	var (
+		o        = s.options.Hedge
+		delay    = o.Delay.Delay()
+		hedges   []hedge[T]
+		used     []grp.ClientID
+		attempts int
+		timer    = time.NewTimer(delay)
+		timerCh  = timer.C
+	)
+	defer timer.Stop()
+	if o.MaxAttempts < 2 {
+		timerCh = nil
+	}
+	send := func() (err error) {
+		attempts++
+		var (
+			h = hedge[T]{
+				results: make(chan core.AsyncResult, 1),
+				start:   time.Now(),
+			}
+			hctx = hks.ContextWithHedge(ctx, attempts)
+		)
+		if group, id, ok := s.unusedClient(used); ok {
+			used = append(used, id)
+			h.f, err = s.dispatchWith(hctx, cmd, deadline,
+				func(deadline time.Time) (core.Seq, grp.ClientID, int, error) {
+					seq, n, err := s.sendTo(group, id, cmd, h.results, deadline)
+					return seq, id, n, err
+				})
+		} else if h.f, err = s.dispatch(hctx, cmd, h.results, deadline); err == nil {
+			used = append(used, h.f.clientID)
+		}
+		if err != nil {
+			return
+		}
+		hedges = append(hedges, h)
+		return
+	}
+	if err = send(); err != nil {
+		return
+	}
+	for {
+		n := len(hedges)
+		cases := make([]reflect.SelectCase, 2*n, 2*n+2)
+		for i := range hedges {
+			cases[i] = reflect.SelectCase{
+				Dir:  reflect.SelectRecv,
+				Chan: reflect.ValueOf(hedges[i].results),
+			}
+			cases[n+i] = reflect.SelectCase{
+				Dir:  reflect.SelectRecv,
+				Chan: reflect.ValueOf(hedges[i].f.ctx.Done()),
+			}
+		}
+		cases = append(cases,
+			reflect.SelectCase{Dir: reflect.SelectRecv,
+				Chan: reflect.ValueOf(ctx.Done())},
+			reflect.SelectCase{Dir: reflect.SelectRecv,
+				Chan: reflect.ValueOf(timerCh)},
+		)
+		chosen, value, _ := reflect.Select(cases)
+		if chosen >= n && chosen < 2*n && ctx.Err() != nil {
+			chosen = 2 * n // the outer ctx is done, all sends are abandoned
+		}
+		switch {
+		case chosen == 2*n:
+			for i := range hedges {
+				err = s.abandon(hedges[i].f)
+			}
+			return nil, err
+		case chosen == 2*n+1:
+			timerCh = nil
+			send()
+			if attempts < o.MaxAttempts {
+				timer.Reset(delay)
+				timerCh = timer.C
+			}
+		case chosen >= n:
+			err = s.abandon(hedges[chosen-n].f)
+			hedges = slices.Delete(hedges, chosen-n, chosen-n+1)
+			if len(hedges) == 0 {
+				return nil, err
+			}
+		default:
+			h := hedges[chosen]
+			result, err = s.onResult(h.f, value.Interface().(core.AsyncResult))
+			hedges = slices.Delete(hedges, chosen, chosen+1)
+			if err != nil && len(hedges) > 0 {
+				continue
+			}
+			if err == nil {
+				o.Delay.Observe(time.Since(h.start))
+			}
+			for i := range hedges {
+				s.forget(hedges[i].f, ErrHedgeLost)
+			}
+			return
+		}
+	}
+}
+
+// unusedClient returns an available client of the BroadcastGroup that is not
+// in the used list. Clients are checked starting from the one that follows the
+// client of the first send, chosen by the client group, so hedges rotate with
+// its dispatch strategy. If the list is empty, it returns false, so the first
+// send is routed by the client group.
+func (s Sender[T]) unusedClient(used []grp.ClientID) (group BroadcastGroup[T],
+	id grp.ClientID, ok bool,
+) {
+	if len(used) == 0 {
+		return
+	}
+	if group, ok = s.group.(BroadcastGroup[T]); !ok {
+		return
+	}
+	var (
+		ids   = group.ClientIDs()
+		start = slices.Index(ids, used[0]) + 1
+	)
+	for i := range ids {
+		id = ids[(start+i)%len(ids)]
+		if !slices.Contains(used, id) && available(group, id) {
+			return group, id, true
+		}
+	}
+	return group, 0, false
+}
+
+type hedge[T any] struct {
+	f       flight[T]
+	results chan core.AsyncResult
+	start   time.Time
+}
@@ -0,0 +1,326 @@
+package sender_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	grp "github.com/cmd-stream/cmd-stream-go/group"
+	"github.com/cmd-stream/core-go"
+	sndr "github.com/cmd-stream/sender-go"
+	hks "github.com/cmd-stream/sender-go/hooks"
+	"github.com/cmd-stream/sender-go/test/mocks"
+	cmocks "github.com/cmd-stream/testkit-go/mocks/core"
+	asserterror "github.com/ymz-ncnk/assert/error"
+	"github.com/ymz-ncnk/mok"
+)
+
+type hedgeableCmd struct {
+	core.Cmd[any]
+}
+
+func (c hedgeableCmd) Hedgeable() bool { return true }
+
+func TestHedging(t *testing.T) {
+	hedgeAll := sndr.WithHedgeable(func(cmd any) bool { return true })
+
+	t.Run("Should return the Result of the hedge and forget the first send",
+		func(t *testing.T) {
+			var (
+				wantResult = cmocks.NewResult()
+				group      = mocks.NewClientGroup().RegisterSend(
+					func(cmd core.Cmd[any], results chan<- core.AsyncResult) (
+						seq core.Seq, clientID grp.ClientID, n int, err error,
+					) {
+						return 1, 1, 10, nil
+					},
+				).RegisterSend(
+					func(cmd core.Cmd[any], results chan<- core.AsyncResult) (
+						seq core.Seq, clientID grp.ClientID, n int, err error,
+					) {
+						results <- core.AsyncResult{Result: wantResult}
+						return 1, 2, 10, nil
+					},
+				).RegisterForget(
+					func(seq core.Seq, clientID grp.ClientID) {
+						asserterror.Equal(seq, 1, t)
+						asserterror.Equal(clientID, 1, t)
+					},
+				)
+				hooks = mocks.NewHooks[any]().RegisterNBeforeSend(2,
+					func(ctx context.Context, cmd core.Cmd[any]) (context.Context, error) {
+						return ctx, nil
+					},
+				).RegisterOnResult(
+					func(ctx context.Context, sentCmd hks.SentCmd[any],
+						recvResult hks.ReceivedResult, err error,
+					) {
+						hedge, _ := hks.HedgeFromContext(ctx)
+						asserterror.Equal(hedge, 2, t)
+					},
+				).RegisterOnTimeout(
+					func(ctx context.Context, sentCmd hks.SentCmd[any], err error) {
+						hedge, _ := hks.HedgeFromContext(ctx)
+						asserterror.Equal(hedge, 1, t)
+						asserterror.EqualError(err, sndr.ErrHedgeLost, t)
+					},
+				)
+				factory = mocks.NewHooksFactory[any]().RegisterNNew(2,
+					func() hks.Hooks[any] { return hooks },
+				)
+				sender = sndr.New(group,
+					sndr.WithHooksFactory[any](factory),
+					sndr.WithHedging[any](sndr.NewFixedHedgeDelay(time.Millisecond),
+						hedgeAll),
+				)
+				mocks = []*mok.Mock{group.Mock, hooks.Mock, factory.Mock}
+			)
+			result, err := sender.Send(context.Background(), cmocks.NewCmd())
+			asserterror.EqualError(err, nil, t)
+			asserterror.EqualDeep(result, core.Result(wantResult), t)
+
+			asserterror.EqualDeep(mok.CheckCalls(mocks), mok.EmptyInfomap, t)
+		})
+
+	t.Run("Should wait for the hedge if the first send fails",
+		func(t *testing.T) {
+			var (
+				wantResult = cmocks.NewResult()
+				sendErr    = make(chan struct{})
+				group      = mocks.NewClientGroup().RegisterSend(
+					func(cmd core.Cmd[any], results chan<- core.AsyncResult) (
+						seq core.Seq, clientID grp.ClientID, n int, err error,
+					) {
+						go func() {
+							<-sendErr
+							results <- core.AsyncResult{Error: errors.New("error")}
+						}()
+						return 1, 1, 10, nil
+					},
+				).RegisterSend(
+					func(cmd core.Cmd[any], results chan<- core.AsyncResult) (
+						seq core.Seq, clientID grp.ClientID, n int, err error,
+					) {
+						close(sendErr)
+						go func() {
+							time.Sleep(10 * time.Millisecond)
+							results <- core.AsyncResult{Result: wantResult}
+						}()
+						return 1, 2, 10, nil
+					},
+				)
+				sender = sndr.New(group,
+					sndr.WithHedging[any](sndr.NewFixedHedgeDelay(time.Millisecond),
+						hedgeAll),
+				)
+				mocks = []*mok.Mock{group.Mock}
+			)
+			result, err := sender.Send(context.Background(), cmocks.NewCmd())
+			asserterror.EqualError(err, nil, t)
+			asserterror.EqualDeep(result, core.Result(wantResult), t)
+
+			asserterror.EqualDeep(mok.CheckCalls(mocks), mok.EmptyInfomap, t)
+		})
+
+	t.Run("Should not hedge Commands that are not hedgeable", func(t *testing.T) {
+		var (
+			ctx, cancel = context.WithTimeout(context.Background(),
+				20*time.Millisecond)
+			group = mocks.NewClientGroup().RegisterSend(
+				func(cmd core.Cmd[any], results chan<- core.AsyncResult) (
+					seq core.Seq, clientID grp.ClientID, n int, err error,
+				) {
+					return 1, 1, 10, nil
+				},
+			).RegisterForget(func(seq core.Seq, clientID grp.ClientID) {})
+			sender = sndr.New(group,
+				sndr.WithHedging[any](sndr.NewFixedHedgeDelay(time.Millisecond)),
+			)
+			mocks = []*mok.Mock{group.Mock}
+		)
+		defer cancel()
+		_, err := sender.Send(ctx, cmocks.NewCmd())
+		asserterror.EqualError(err, sndr.ErrTimeout, t)
+
+		asserterror.EqualDeep(mok.CheckCalls(mocks), mok.EmptyInfomap, t)
+	})
+
+	t.Run("Should forget all sends if the ctx is done", func(t *testing.T) {
+		var (
+			ctx, cancel = context.WithTimeout(context.Background(),
+				20*time.Millisecond)
+			group = mocks.NewClientGroup().RegisterSend(
+				func(cmd core.Cmd[any], results chan<- core.AsyncResult) (
+					seq core.Seq, clientID grp.ClientID, n int, err error,
+				) {
+					return 1, 1, 10, nil
+				},
+			).RegisterSend(
+				func(cmd core.Cmd[any], results chan<- core.AsyncResult) (
+					seq core.Seq, clientID grp.ClientID, n int, err error,
+				) {
+					return 1, 2, 10, nil
+				},
+			).RegisterForget(
+				func(seq core.Seq, clientID grp.ClientID) {
+					asserterror.Equal(clientID, 1, t)
+				},
+			).RegisterForget(
+				func(seq core.Seq, clientID grp.ClientID) {
+					asserterror.Equal(clientID, 2, t)
+				},
+			)
+			sender = sndr.New(group,
+				sndr.WithHedging[any](sndr.NewFixedHedgeDelay(time.Millisecond)),
+			)
+			mocks = []*mok.Mock{group.Mock}
+		)
+		defer cancel()
+		_, err := sender.Send(ctx, hedgeableCmd{cmocks.NewCmd()})
+		asserterror.EqualError(err, sndr.ErrTimeout, t)
+
+		asserterror.EqualDeep(mok.CheckCalls(mocks), mok.EmptyInfomap, t)
+	})
+
+	t.Run("Should abandon a send once its hooks ctx is done", func(t *testing.T) {
+		var (
+			hctx, cancel = context.WithTimeout(context.Background(),
+				10*time.Millisecond)
+			group = mocks.NewClientGroup().RegisterSend(
+				func(cmd core.Cmd[any], results chan<- core.AsyncResult) (
+					seq core.Seq, clientID grp.ClientID, n int, err error,
+				) {
+					return 1, 1, 10, nil
+				},
+			).RegisterForget(
+				func(seq core.Seq, clientID grp.ClientID) {
+					asserterror.Equal(clientID, 1, t)
+				},
+			)
+			hooks = mocks.NewHooks[any]().RegisterBeforeSend(
+				func(ctx context.Context, cmd core.Cmd[any]) (context.Context, error) {
+					return hctx, nil
+				},
+			).RegisterOnTimeout(
+				func(ctx context.Context, sentCmd hks.SentCmd[any], err error) {
+					asserterror.EqualError(err, sndr.ErrTimeout, t)
+				},
+			)
+			factory = mocks.NewHooksFactory[any]().RegisterNew(
+				func() hks.Hooks[any] { return hooks },
+			)
+			sender = sndr.New(group,
+				sndr.WithHooksFactory[any](factory),
+				sndr.WithHedging[any](sndr.NewFixedHedgeDelay(time.Hour), hedgeAll),
+			)
+			mocks = []*mok.Mock{group.Mock, hooks.Mock, factory.Mock}
+		)
+		defer cancel()
+		_, err := sender.Send(context.Background(), cmocks.NewCmd())
+		asserterror.EqualError(err, sndr.ErrTimeout, t)
+
+		asserterror.EqualDeep(mok.CheckCalls(mocks), mok.EmptyInfomap, t)
+	})
+
+	t.Run("Should send hedges through unused clients of a BroadcastGroup",
+		func(t *testing.T) {
+			var (
+				wantResult = cmocks.NewResult()
+				group      = mocks.NewBroadcastGroup()
+			)
+			group.RegisterSend(
+				func(cmd core.Cmd[any], results chan<- core.AsyncResult) (
+					seq core.Seq, clientID grp.ClientID, n int, err error,
+				) {
+					return 1, 1, 10, nil
+				},
+			)
+			group.RegisterClientIDs(
+				func() []grp.ClientID { return []grp.ClientID{0, 1, 2} },
+			).RegisterSendTo(
+				func(clientID grp.ClientID, cmd core.Cmd[any],
+					results chan<- core.AsyncResult,
+				) (seq core.Seq, n int, err error) {
+					asserterror.Equal(clientID, 2, t)
+					results <- core.AsyncResult{Result: wantResult}
+					return 2, 10, nil
+				},
+			).RegisterForget(
+				func(seq core.Seq, clientID grp.ClientID) {
+					asserterror.Equal(seq, 1, t)
+					asserterror.Equal(clientID, 1, t)
+				},
+			)
+			sender := sndr.New[any](group,
+				sndr.WithHedging[any](sndr.NewFixedHedgeDelay(time.Millisecond),
+					hedgeAll),
+			)
+			result, err := sender.Send(context.Background(), cmocks.NewCmd())
+			asserterror.EqualError(err, nil, t)
+			asserterror.EqualDeep(result, core.Result(wantResult), t)
+
+			asserterror.EqualDeep(mok.CheckCalls([]*mok.Mock{group.Mock}),
+				mok.EmptyInfomap, t)
+		})
+
+	t.Run("Should keep hedging if a hedge send fails", func(t *testing.T) {
+		var (
+			wantResult = cmocks.NewResult()
+			clientIDs  = func() []grp.ClientID { return []grp.ClientID{0, 1, 2} }
+			group      = mocks.NewBroadcastGroup()
+		)
+		group.RegisterSend(
+			func(cmd core.Cmd[any], results chan<- core.AsyncResult) (
+				seq core.Seq, clientID grp.ClientID, n int, err error,
+			) {
+				return 1, 0, 10, nil
+			},
+		)
+		group.RegisterClientIDs(clientIDs).RegisterSendTo(
+			func(clientID grp.ClientID, cmd core.Cmd[any],
+				results chan<- core.AsyncResult,
+			) (seq core.Seq, n int, err error) {
+				asserterror.Equal(clientID, 1, t)
+				return 0, 0, errors.New("SendTo error")
+			},
+		).RegisterClientIDs(clientIDs).RegisterSendTo(
+			func(clientID grp.ClientID, cmd core.Cmd[any],
+				results chan<- core.AsyncResult,
+			) (seq core.Seq, n int, err error) {
+				asserterror.Equal(clientID, 2, t)
+				results <- core.AsyncResult{Result: wantResult}
+				return 2, 10, nil
+			},
+		).RegisterForget(
+			func(seq core.Seq, clientID grp.ClientID) {
+				asserterror.Equal(seq, 1, t)
+				asserterror.Equal(clientID, 0, t)
+			},
+		)
+		sender := sndr.New[any](group,
+			sndr.WithHedging[any](sndr.NewFixedHedgeDelay(time.Millisecond),
+				hedgeAll, sndr.WithHedgeMaxAttempts(3)),
+		)
+		result, err := sender.Send(context.Background(), cmocks.NewCmd())
+		asserterror.EqualError(err, nil, t)
+		asserterror.EqualDeep(result, core.Result(wantResult), t)
+
+		asserterror.EqualDeep(mok.CheckCalls([]*mok.Mock{group.Mock}),
+			mok.EmptyInfomap, t)
+	})
+}
+
+func TestPercentileHedgeDelay(t *testing.T) {
+	delay := sndr.NewPercentileHedgeDelay(0.9, 10, time.Second)
+	for i := 1; i < 10; i++ {
+		delay.Observe(time.Duration(i) * time.Millisecond)
+	}
+	asserterror.Equal(delay.Delay(), time.Second, t)
+
+	delay.Observe(10 * time.Millisecond)
+	asserterror.Equal(delay.Delay(), 9*time.Millisecond, t)
+
+	delay.Observe(100 * time.Millisecond) // replaces 1ms
+	asserterror.Equal(delay.Delay(), 10*time.Millisecond, t)
+}
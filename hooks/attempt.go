@@ -0,0 +1,35 @@
+package hooks
+
+import "context"
+
+type attemptKey struct{}
+
+// ContextWithAttempt returns a copy of the ctx that carries the attempt
+// number.
+func ContextWithAttempt(ctx context.Context, attempt int) context.Context {
+	return context.WithValue(ctx, attemptKey{}, attempt)
+}
+
+// AttemptFromContext returns the attempt number of the current send
+// operation, attempts are numbered starting from 1. ok == false if the ctx
+// carries no attempt number (for example, when the retry is disabled).
+func AttemptFromContext(ctx context.Context) (attempt int, ok bool) {
+	attempt, ok = ctx.Value(attemptKey{}).(int)
+	return
+}
+
+type hedgeKey struct{}
+
+// ContextWithHedge returns a copy of the ctx that carries the hedge number.
+func ContextWithHedge(ctx context.Context, hedge int) context.Context {
+	return context.WithValue(ctx, hedgeKey{}, hedge)
+}
+
+// HedgeFromContext returns the number of the hedged send of the same Command,
+// the first send is numbered 1. It allows hooks to report which hedge won.
+// ok == false if the ctx carries no hedge number (for example, when hedging
+// is disabled).
+func HedgeFromContext(ctx context.Context) (hedge int, ok bool) {
+	hedge, ok = ctx.Value(hedgeKey{}).(int)
+	return
+}
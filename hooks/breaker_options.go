@@ -0,0 +1,101 @@
+package hooks
+
+import "time"
+
+// WindowType defines how the SlidingWindowBreaker limits its window.
+type WindowType int
+
+const (
+	// CountBasedWindow keeps outcomes of the last WindowSize calls.
+	CountBasedWindow WindowType = iota
+	// TimeBasedWindow keeps outcomes of the calls made during the last
+	// WindowDuration.
+	TimeBasedWindow
+)
+
+type BreakerOptions struct {
+	WindowType            WindowType
+	WindowSize            int
+	WindowDuration        time.Duration
+	BucketsCount          int
+	MinCalls              int
+	FailureRateThreshold  float64
+	SlowCallRateThreshold float64
+	SlowCallDuration      time.Duration
+	OpenDuration          time.Duration
+	HalfOpenProbes        int
+	OnStateChange         StateChangeFn
+	Clock                 Clock
+}
+
+type SetBreakerOption func(o *BreakerOptions)
+
+// WithBreakerCountWindow makes the breaker to consider the last size calls.
+func WithBreakerCountWindow(size int) SetBreakerOption {
+	return func(o *BreakerOptions) {
+		o.WindowType = CountBasedWindow
+		o.WindowSize = size
+	}
+}
+
+// WithBreakerTimeWindow makes the breaker to consider the calls made during
+// the last d. The window slides in steps of d / bucketsCount.
+func WithBreakerTimeWindow(d time.Duration, bucketsCount int) SetBreakerOption {
+	return func(o *BreakerOptions) {
+		o.WindowType = TimeBasedWindow
+		o.WindowDuration = d
+		o.BucketsCount = bucketsCount
+	}
+}
+
+// WithBreakerMinCalls sets the minimum number of calls in the window required
+// before the failure and slow call rates are evaluated.
+func WithBreakerMinCalls(n int) SetBreakerOption {
+	return func(o *BreakerOptions) { o.MinCalls = n }
+}
+
+// WithBreakerFailureRate sets the failure rate (in the range (0, 1]) at which
+// the breaker opens.
+func WithBreakerFailureRate(rate float64) SetBreakerOption {
+	return func(o *BreakerOptions) { o.FailureRateThreshold = rate }
+}
+
+// WithBreakerSlowCallRate sets the slow call rate (in the range (0, 1]) at
+// which the breaker opens, calls that take at least d are considered slow.
+func WithBreakerSlowCallRate(rate float64, d time.Duration) SetBreakerOption {
+	return func(o *BreakerOptions) {
+		o.SlowCallRateThreshold = rate
+		o.SlowCallDuration = d
+	}
+}
+
+// WithBreakerOpenDuration sets how long the breaker stays open before becoming
+// half-open.
+func WithBreakerOpenDuration(d time.Duration) SetBreakerOption {
+	return func(o *BreakerOptions) { o.OpenDuration = d }
+}
+
+// WithBreakerHalfOpenProbes sets the number of calls allowed in the half-open
+// state.
+func WithBreakerHalfOpenProbes(n int) SetBreakerOption {
+	return func(o *BreakerOptions) { o.HalfOpenProbes = n }
+}
+
+// WithBreakerOnStateChange sets a callback that is called on every state
+// change. It is called outside of the breaker's lock.
+func WithBreakerOnStateChange(fn StateChangeFn) SetBreakerOption {
+	return func(o *BreakerOptions) { o.OnStateChange = fn }
+}
+
+// WithBreakerClock sets the clock used by the breaker.
+func WithBreakerClock(clock Clock) SetBreakerOption {
+	return func(o *BreakerOptions) { o.Clock = clock }
+}
+
+func ApplyBreaker(ops []SetBreakerOption, o *BreakerOptions) {
+	for i := range ops {
+		if ops[i] != nil {
+			ops[i](o)
+		}
+	}
+}
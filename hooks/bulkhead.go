@@ -0,0 +1,131 @@
+package hooks
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+type BulkheadOptions struct {
+	MaxQueue int
+	MaxWait  time.Duration
+}
+
+type SetBulkheadOption func(o *BulkheadOptions)
+
+// WithBulkheadMaxQueue sets the maximum number of callers waiting for a free
+// slot. By default, it is 0, and callers do not wait at all.
+func WithBulkheadMaxQueue(n int) SetBulkheadOption {
+	return func(o *BulkheadOptions) { o.MaxQueue = n }
+}
+
+// WithBulkheadMaxWait sets how long a caller can wait for a free slot. If 0,
+// the wait is limited only by the ctx.
+func WithBulkheadMaxWait(d time.Duration) SetBulkheadOption {
+	return func(o *BulkheadOptions) { o.MaxWait = d }
+}
+
+func ApplyBulkhead(ops []SetBulkheadOption, o *BulkheadOptions) {
+	for i := range ops {
+		if ops[i] != nil {
+			ops[i](o)
+		}
+	}
+}
+
+// NewBulkhead creates a new Bulkhead.
+func NewBulkhead(maxConcurrent int, ops ...SetBulkheadOption) *Bulkhead {
+	o := BulkheadOptions{}
+	ApplyBulkhead(ops, &o)
+	return &Bulkhead{options: o, max: maxConcurrent}
+}
+
+// Bulkhead limits the number of concurrent operations. Callers that find no
+// free slot are queued in FIFO order.
+//
+// Bulkhead is safe for concurrent use.
+type Bulkhead struct {
+	options BulkheadOptions
+	max     int
+
+	mu      sync.Mutex
+	inUse   int
+	waiters []chan struct{}
+}
+
+// Acquire takes a slot. If there is no free one, it waits for it according to
+// the options. Returns ErrBulkheadFull if the slot cannot be taken (when the
+// ctx is done, the returned error wraps also the ctx error).
+func (b *Bulkhead) Acquire(ctx context.Context) (err error) {
+	b.mu.Lock()
+	if b.inUse < b.max && len(b.waiters) == 0 {
+		b.inUse++
+		b.mu.Unlock()
+		return
+	}
+	if len(b.waiters) >= b.options.MaxQueue {
+		b.mu.Unlock()
+		return ErrBulkheadFull
+	}
+	ready := make(chan struct{})
+	b.waiters = append(b.waiters, ready)
+	b.mu.Unlock()
+
+	var timeout <-chan time.Time
+	if b.options.MaxWait > 0 {
+		timer := time.NewTimer(b.options.MaxWait)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	select {
+	case <-ready:
+		return
+	case <-ctx.Done():
+		err = fmt.Errorf("%w: %w", ErrBulkheadFull, ctx.Err())
+	case <-timeout:
+		err = ErrBulkheadFull
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	select {
+	case <-ready:
+		// The slot was handed over just now, give it back.
+		b.release()
+	default:
+		b.waiters = slices.DeleteFunc(b.waiters,
+			func(ch chan struct{}) bool { return ch == ready })
+	}
+	return
+}
+
+// Release frees the slot taken by Acquire.
+func (b *Bulkhead) Release() {
+	b.mu.Lock()
+	b.release()
+	b.mu.Unlock()
+}
+
+// InUse returns the number of taken slots.
+func (b *Bulkhead) InUse() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.inUse
+}
+
+// Queued returns the number of callers waiting for a free slot.
+func (b *Bulkhead) Queued() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.waiters)
+}
+
+func (b *Bulkhead) release() {
+	if len(b.waiters) > 0 {
+		close(b.waiters[0])
+		b.waiters = b.waiters[1:]
+		return
+	}
+	b.inUse--
+}
@@ -0,0 +1,124 @@
+package hooks
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/cmd-stream/core-go"
+)
+
+// NewBulkheadHooksFactory creates a new BulkheadHooksFactory.
+//
+// The bulkhead limits all Commands, while perType limits Commands of the
+// specific type (for example, reflect.TypeFor[MyCmd]()). Both may be nil, the
+// perType map must not be modified after the factory is created.
+func NewBulkheadHooksFactory[T any](bulkhead *Bulkhead,
+	perType map[reflect.Type]*Bulkhead,
+	factory HooksFactory[T],
+) BulkheadHooksFactory[T] {
+	return BulkheadHooksFactory[T]{bulkhead, perType, factory}
+}
+
+// BulkheadHooksFactory can be used to create hooks that limit the number of
+// in-flight Commands.
+type BulkheadHooksFactory[T any] struct {
+	bulkhead *Bulkhead
+	perType  map[reflect.Type]*Bulkhead
+	factory  HooksFactory[T]
+}
+
+func (f BulkheadHooksFactory[T]) New() Hooks[T] {
+	return NewBulkheadHooks(f.bulkhead, f.perType, f.factory.New())
+}
+
+// NewBulkheadHooks creates a new BulkheadHooks.
+func NewBulkheadHooks[T any](bulkhead *Bulkhead,
+	perType map[reflect.Type]*Bulkhead,
+	hooks Hooks[T],
+) BulkheadHooks[T] {
+	return BulkheadHooks[T]{bulkhead, perType, hooks, new([]*Bulkhead)}
+}
+
+// BulkheadHooks takes slots in the bulkheads before sending, and releases
+// them once the Command is completed: in OnError, OnTimeout, or in OnResult
+// when the last Result (or an error) is received.
+//
+// If a slot cannot be taken, BeforeSend returns an error that wraps
+// ErrBulkheadFull.
+type BulkheadHooks[T any] struct {
+	bulkhead *Bulkhead
+	perType  map[reflect.Type]*Bulkhead
+	hooks    Hooks[T]
+	acquired *[]*Bulkhead
+}
+
+func (h BulkheadHooks[T]) BeforeSend(ctx context.Context, cmd core.Cmd[T]) (
+	actx context.Context, err error,
+) {
+	if b := h.perType[reflect.TypeOf(cmd)]; b != nil {
+		if err = h.acquire(ctx, b); err != nil {
+			return ctx, err
+		}
+	}
+	if h.bulkhead != nil {
+		if err = h.acquire(ctx, h.bulkhead); err != nil {
+			h.release()
+			return ctx, err
+		}
+	}
+	actx, err = h.hooks.BeforeSend(ctx, cmd)
+	if err != nil {
+		h.release()
+	}
+	return
+}
+
+func (h BulkheadHooks[T]) OnError(ctx context.Context, sentCmd SentCmd[T],
+	err error,
+) {
+	h.release()
+	h.hooks.OnError(ctx, sentCmd, err)
+}
+
+func (h BulkheadHooks[T]) OnResult(ctx context.Context, sentCmd SentCmd[T],
+	recvResult ReceivedResult, err error,
+) {
+	if err != nil || recvResult.Result == nil || recvResult.Result.LastOne() {
+		h.release()
+	}
+	h.hooks.OnResult(ctx, sentCmd, recvResult, err)
+}
+
+func (h BulkheadHooks[T]) OnTimeout(ctx context.Context, sentCmd SentCmd[T],
+	err error,
+) {
+	h.release()
+	h.hooks.OnTimeout(ctx, sentCmd, err)
+}
+
+func (h BulkheadHooks[T]) OnWritten(ctx context.Context, sentCmd SentCmd[T]) {
+	h.release()
+	OnWritten(h.hooks, ctx, sentCmd)
+}
+
+func (h BulkheadHooks[T]) OnCacheHit(ctx context.Context, cmd core.Cmd[T],
+	hit CacheHit,
+) {
+	OnCacheHit(h.hooks, ctx, cmd, hit)
+}
+
+func (h BulkheadHooks[T]) acquire(ctx context.Context, b *Bulkhead) error {
+	if err := b.Acquire(ctx); err != nil {
+		return err
+	}
+	*h.acquired = append(*h.acquired, b)
+	return nil
+}
+
+// release frees all taken slots, it is safe to call it several times.
+func (h BulkheadHooks[T]) release() {
+	for _, b := range *h.acquired {
+		b.Release()
+	}
+	*h.acquired = nil
+}
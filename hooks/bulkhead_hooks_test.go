@@ -0,0 +1,110 @@
+package hooks_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/cmd-stream/core-go"
+	hks "github.com/cmd-stream/sender-go/hooks"
+	cmocks "github.com/cmd-stream/testkit-go/mocks/core"
+	asserterror "github.com/ymz-ncnk/assert/error"
+	"github.com/ymz-ncnk/mok"
+
+	"github.com/cmd-stream/sender-go/test/mocks"
+)
+
+func TestBulkheadHooks(t *testing.T) {
+	t.Run("Should release the slot on the last Result", func(t *testing.T) {
+		var (
+			bulkhead   = hks.NewBulkhead(1)
+			innerHooks = mocks.NewHooks[any]().RegisterBeforeSend(
+				func(ctx context.Context, cmd core.Cmd[any]) (context.Context, error) {
+					return ctx, nil
+				},
+			).RegisterNOnResult(2,
+				func(ctx context.Context, sentCmd hks.SentCmd[any],
+					recvResult hks.ReceivedResult, err error,
+				) {
+				},
+			)
+			hooks = hks.NewBulkheadHooks(bulkhead, nil, innerHooks)
+			mocks = []*mok.Mock{innerHooks.Mock}
+		)
+		_, err := hooks.BeforeSend(context.Background(), cmocks.NewCmd())
+		asserterror.EqualError(err, nil, t)
+		asserterror.Equal(bulkhead.InUse(), 1, t)
+
+		hooks.OnResult(context.Background(), hks.SentCmd[any]{},
+			hks.ReceivedResult{Result: cmocks.NewResult().RegisterLastOne(
+				func() bool { return false },
+			)}, nil)
+		asserterror.Equal(bulkhead.InUse(), 1, t)
+
+		hooks.OnResult(context.Background(), hks.SentCmd[any]{},
+			hks.ReceivedResult{Result: cmocks.NewResult().RegisterLastOne(
+				func() bool { return true },
+			)}, nil)
+		asserterror.Equal(bulkhead.InUse(), 0, t)
+
+		asserterror.EqualDeep(mok.CheckCalls(mocks), mok.EmptyInfomap, t)
+	})
+
+	t.Run("Should release the slot on timeout", func(t *testing.T) {
+		var (
+			bulkhead   = hks.NewBulkhead(1)
+			innerHooks = mocks.NewHooks[any]().RegisterBeforeSend(
+				func(ctx context.Context, cmd core.Cmd[any]) (context.Context, error) {
+					return ctx, nil
+				},
+			).RegisterOnTimeout(
+				func(ctx context.Context, sentCmd hks.SentCmd[any], err error) {},
+			)
+			hooks = hks.NewBulkheadHooks(bulkhead, nil, innerHooks)
+			mocks = []*mok.Mock{innerHooks.Mock}
+		)
+		hooks.BeforeSend(context.Background(), cmocks.NewCmd())
+		hooks.OnTimeout(context.Background(), hks.SentCmd[any]{},
+			errors.New("timeout"))
+		asserterror.Equal(bulkhead.InUse(), 0, t)
+
+		asserterror.EqualDeep(mok.CheckCalls(mocks), mok.EmptyInfomap, t)
+	})
+
+	t.Run("Should limit Commands of the specific type", func(t *testing.T) {
+		var (
+			cmd      = cmocks.NewCmd()
+			bulkhead = hks.NewBulkhead(1)
+			perType  = map[reflect.Type]*hks.Bulkhead{
+				reflect.TypeOf(cmd): hks.NewBulkhead(0),
+			}
+			hooks = hks.NewBulkheadHooks[any](bulkhead, perType, nil)
+		)
+		_, err := hooks.BeforeSend(context.Background(), cmd)
+		asserterror.EqualError(err, hks.ErrBulkheadFull, t)
+		asserterror.Equal(bulkhead.InUse(), 0, t)
+	})
+
+	t.Run("Should release slots if the inner BeforeSend fails",
+		func(t *testing.T) {
+			var (
+				wantErr    = errors.New("BeforeSend error")
+				bulkhead   = hks.NewBulkhead(1)
+				innerHooks = mocks.NewHooks[any]().RegisterBeforeSend(
+					func(ctx context.Context, cmd core.Cmd[any]) (context.Context,
+						error,
+					) {
+						return ctx, wantErr
+					},
+				)
+				hooks = hks.NewBulkheadHooks(bulkhead, nil, innerHooks)
+				mocks = []*mok.Mock{innerHooks.Mock}
+			)
+			_, err := hooks.BeforeSend(context.Background(), cmocks.NewCmd())
+			asserterror.EqualError(err, wantErr, t)
+			asserterror.Equal(bulkhead.InUse(), 0, t)
+
+			asserterror.EqualDeep(mok.CheckCalls(mocks), mok.EmptyInfomap, t)
+		})
+}
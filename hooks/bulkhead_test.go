@@ -0,0 +1,75 @@
+package hooks_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	hks "github.com/cmd-stream/sender-go/hooks"
+	asserterror "github.com/ymz-ncnk/assert/error"
+)
+
+func TestBulkhead(t *testing.T) {
+	t.Run("Should limit concurrent operations", func(t *testing.T) {
+		b := hks.NewBulkhead(2)
+		asserterror.EqualError(b.Acquire(context.Background()), nil, t)
+		asserterror.EqualError(b.Acquire(context.Background()), nil, t)
+		asserterror.EqualError(b.Acquire(context.Background()), hks.ErrBulkheadFull,
+			t)
+		asserterror.Equal(b.InUse(), 2, t)
+
+		b.Release()
+		asserterror.Equal(b.InUse(), 1, t)
+		asserterror.EqualError(b.Acquire(context.Background()), nil, t)
+	})
+
+	t.Run("Should hand over the slot to the waiter", func(t *testing.T) {
+		var (
+			b    = hks.NewBulkhead(1, hks.WithBulkheadMaxQueue(1))
+			errs = make(chan error, 1)
+		)
+		asserterror.EqualError(b.Acquire(context.Background()), nil, t)
+		go func() { errs <- b.Acquire(context.Background()) }()
+		for b.Queued() == 0 {
+			time.Sleep(time.Millisecond)
+		}
+		b.Release()
+		asserterror.EqualError(<-errs, nil, t)
+		asserterror.Equal(b.InUse(), 1, t)
+	})
+
+	t.Run("Should return ErrBulkheadFull if the queue is full",
+		func(t *testing.T) {
+			var (
+				b           = hks.NewBulkhead(0, hks.WithBulkheadMaxQueue(1))
+				ctx, cancel = context.WithCancel(context.Background())
+			)
+			defer cancel()
+			go b.Acquire(ctx)
+			for b.Queued() == 0 {
+				time.Sleep(time.Millisecond)
+			}
+			asserterror.EqualError(b.Acquire(context.Background()),
+				hks.ErrBulkheadFull, t)
+		})
+
+	t.Run("Should return ErrBulkheadFull after MaxWait", func(t *testing.T) {
+		b := hks.NewBulkhead(0, hks.WithBulkheadMaxQueue(1),
+			hks.WithBulkheadMaxWait(10*time.Millisecond))
+		asserterror.EqualError(b.Acquire(context.Background()), hks.ErrBulkheadFull,
+			t)
+		asserterror.Equal(b.Queued(), 0, t)
+	})
+
+	t.Run("Should wrap the ctx error", func(t *testing.T) {
+		var (
+			b           = hks.NewBulkhead(0, hks.WithBulkheadMaxQueue(1))
+			ctx, cancel = context.WithCancel(context.Background())
+		)
+		cancel()
+		err := b.Acquire(ctx)
+		asserterror.Equal(errors.Is(err, hks.ErrBulkheadFull), true, t)
+		asserterror.Equal(errors.Is(err, context.Canceled), true, t)
+	})
+}
@@ -0,0 +1,37 @@
+package hooks
+
+import (
+	"context"
+
+	"github.com/cmd-stream/core-go"
+)
+
+// CacheHit describes a Result served from the cache instead of being received
+// from the server.
+type CacheHit struct {
+	Key    string
+	Result core.Result
+	// Err is set if an error was cached.
+	Err error
+	// Stale is true if the cached Result has expired and is being
+	// revalidated.
+	Stale bool
+}
+
+// CacheHooks is an optional interface of Hooks. When the Result of a Command
+// is served from the cache (see sender.WithCache), no other hooks are called
+// for it, so cache hits do not affect metrics of sent Commands. OnCacheHit is
+// called instead.
+type CacheHooks[T any] interface {
+	OnCacheHit(ctx context.Context, cmd core.Cmd[T], hit CacheHit)
+}
+
+// OnCacheHit calls hooks.OnCacheHit if the hooks implement CacheHooks. Hooks
+// that wrap other hooks use it to pass cache hits on.
+func OnCacheHit[T any](hooks Hooks[T], ctx context.Context, cmd core.Cmd[T],
+	hit CacheHit,
+) {
+	if h, ok := hooks.(CacheHooks[T]); ok {
+		h.OnCacheHit(ctx, cmd, hit)
+	}
+}
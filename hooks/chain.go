@@ -0,0 +1,100 @@
+package hooks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cmd-stream/core-go"
+)
+
+// Chain combines several HooksFactories into one. With no factories it
+// returns NoopHooksFactory.
+//
+// Factories that wrap an inner factory (like CircuitBreakerHooksFactory) can
+// be chained with NoopHooksFactory as the inner one.
+func Chain[T any](factories ...HooksFactory[T]) HooksFactory[T] {
+	switch len(factories) {
+	case 0:
+		return NoopHooksFactory[T]{}
+	case 1:
+		return factories[0]
+	default:
+		return ChainHooksFactory[T]{factories}
+	}
+}
+
+// ChainHooksFactory creates ChainHooks.
+type ChainHooksFactory[T any] struct {
+	factories []HooksFactory[T]
+}
+
+func (f ChainHooksFactory[T]) New() Hooks[T] {
+	hooks := make([]Hooks[T], len(f.factories))
+	for i := range f.factories {
+		hooks[i] = f.factories[i].New()
+	}
+	return ChainHooks[T](hooks)
+}
+
+// ChainHooks runs BeforeSend of each Hooks in order, passing the ctx returned
+// by one to the next. If one of them fails, the chain stops, and OnError is
+// called (in reverse order) for the preceding Hooks with an error that wraps
+// both ErrAborted and the BeforeSend error.
+//
+// OnError, OnResult and OnTimeout are called in reverse order.
+type ChainHooks[T any] []Hooks[T]
+
+func (h ChainHooks[T]) BeforeSend(ctx context.Context, cmd core.Cmd[T]) (
+	context.Context, error,
+) {
+	for i := range h {
+		actx, err := h[i].BeforeSend(ctx, cmd)
+		if err != nil {
+			abortErr := fmt.Errorf("%w: %w", ErrAborted, err)
+			for j := i - 1; j >= 0; j-- {
+				h[j].OnError(ctx, SentCmd[T]{Cmd: cmd}, abortErr)
+			}
+			return ctx, err
+		}
+		ctx = actx
+	}
+	return ctx, nil
+}
+
+func (h ChainHooks[T]) OnError(ctx context.Context, sentCmd SentCmd[T],
+	err error,
+) {
+	for i := len(h) - 1; i >= 0; i-- {
+		h[i].OnError(ctx, sentCmd, err)
+	}
+}
+
+func (h ChainHooks[T]) OnResult(ctx context.Context, sentCmd SentCmd[T],
+	recvResult ReceivedResult, err error,
+) {
+	for i := len(h) - 1; i >= 0; i-- {
+		h[i].OnResult(ctx, sentCmd, recvResult, err)
+	}
+}
+
+func (h ChainHooks[T]) OnTimeout(ctx context.Context, sentCmd SentCmd[T],
+	err error,
+) {
+	for i := len(h) - 1; i >= 0; i-- {
+		h[i].OnTimeout(ctx, sentCmd, err)
+	}
+}
+
+func (h ChainHooks[T]) OnWritten(ctx context.Context, sentCmd SentCmd[T]) {
+	for i := len(h) - 1; i >= 0; i-- {
+		OnWritten(h[i], ctx, sentCmd)
+	}
+}
+
+func (h ChainHooks[T]) OnCacheHit(ctx context.Context, cmd core.Cmd[T],
+	hit CacheHit,
+) {
+	for i := len(h) - 1; i >= 0; i-- {
+		OnCacheHit(h[i], ctx, cmd, hit)
+	}
+}
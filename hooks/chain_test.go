@@ -0,0 +1,141 @@
+package hooks_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cmd-stream/core-go"
+	hks "github.com/cmd-stream/sender-go/hooks"
+	cmocks "github.com/cmd-stream/testkit-go/mocks/core"
+	asserterror "github.com/ymz-ncnk/assert/error"
+	"github.com/ymz-ncnk/mok"
+
+	"github.com/cmd-stream/sender-go/test/helpers"
+	"github.com/cmd-stream/sender-go/test/mocks"
+)
+
+type chainKey int
+
+func TestChain(t *testing.T) {
+	t.Run("Should return NoopHooksFactory if there are no factories",
+		func(t *testing.T) {
+			asserterror.Equal[hks.HooksFactory[any]](hks.Chain[any](),
+				hks.NoopHooksFactory[any]{}, t)
+		})
+
+	t.Run("Should thread the ctx and call completion callbacks in reverse order",
+		func(t *testing.T) {
+			var (
+				calls   []string
+				sentCmd = hks.SentCmd[any]{Seq: 1}
+				first   = mocks.NewHooks[any]().RegisterBeforeSend(
+					func(ctx context.Context, cmd core.Cmd[any]) (context.Context, error) {
+						calls = append(calls, "first.BeforeSend")
+						return context.WithValue(ctx, chainKey(1), 1), nil
+					},
+				).RegisterOnResult(
+					func(ctx context.Context, sentCmd hks.SentCmd[any],
+						recvResult hks.ReceivedResult, err error,
+					) {
+						calls = append(calls, "first.OnResult")
+					},
+				)
+				second = mocks.NewHooks[any]().RegisterBeforeSend(
+					func(ctx context.Context, cmd core.Cmd[any]) (context.Context, error) {
+						calls = append(calls, "second.BeforeSend")
+						asserterror.Equal(ctx.Value(chainKey(1)), any(1), t)
+						return context.WithValue(ctx, chainKey(2), 2), nil
+					},
+				).RegisterOnResult(
+					func(ctx context.Context, sentCmd hks.SentCmd[any],
+						recvResult hks.ReceivedResult, err error,
+					) {
+						calls = append(calls, "second.OnResult")
+					},
+				)
+				factory = hks.Chain[any](newFactory(first), newFactory(second))
+				mocks   = []*mok.Mock{first.Mock, second.Mock}
+			)
+			hooks := factory.New()
+			ctx, err := hooks.BeforeSend(context.Background(), cmocks.NewCmd())
+			asserterror.EqualError(err, nil, t)
+			asserterror.Equal(ctx.Value(chainKey(2)), any(2), t)
+			hooks.OnResult(ctx, sentCmd, hks.ReceivedResult{}, nil)
+
+			asserterror.EqualDeep(calls, []string{
+				"first.BeforeSend", "second.BeforeSend",
+				"second.OnResult", "first.OnResult",
+			}, t)
+			asserterror.EqualDeep(mok.CheckCalls(mocks), mok.EmptyInfomap, t)
+		})
+
+	t.Run("Should unwind the chain if BeforeSend fails", func(t *testing.T) {
+		var (
+			cmd     = cmocks.NewCmd()
+			wantErr = errors.New("BeforeSend error")
+			first   = mocks.NewHooks[any]().RegisterBeforeSend(
+				func(ctx context.Context, cmd core.Cmd[any]) (context.Context, error) {
+					return ctx, nil
+				},
+			).RegisterOnError(
+				func(ctx context.Context, sentCmd hks.SentCmd[any], err error) {
+					asserterror.EqualDeep(sentCmd, hks.SentCmd[any]{Cmd: cmd}, t)
+					asserterror.Equal(errors.Is(err, hks.ErrAborted), true, t)
+					asserterror.Equal(errors.Is(err, wantErr), true, t)
+				},
+			)
+			second = mocks.NewHooks[any]().RegisterBeforeSend(
+				func(ctx context.Context, cmd core.Cmd[any]) (context.Context, error) {
+					return ctx, wantErr
+				},
+			)
+			third   = *mocks.NewHooks[any]()
+			factory = hks.Chain[any](newFactory(first), newFactory(second),
+				newFactory(third))
+			mocks = []*mok.Mock{first.Mock, second.Mock, third.Mock}
+		)
+		_, err := factory.New().BeforeSend(context.Background(), cmd)
+		asserterror.EqualError(err, wantErr, t)
+
+		asserterror.EqualDeep(mok.CheckCalls(mocks), mok.EmptyInfomap, t)
+	})
+
+	t.Run("Should release the half-open probe of an aborted Command",
+		func(t *testing.T) {
+			var (
+				clock = helpers.NewClock(time.Unix(0, 0))
+				cb    = hks.NewSlidingWindowBreaker(
+					hks.WithBreakerCountWindow(1),
+					hks.WithBreakerMinCalls(1),
+					hks.WithBreakerOpenDuration(time.Second),
+					hks.WithBreakerHalfOpenProbes(1),
+					hks.WithBreakerClock(clock),
+				)
+				wantErr = errors.New("BeforeSend error")
+				failing = mocks.NewHooks[any]().RegisterBeforeSend(
+					func(ctx context.Context, cmd core.Cmd[any]) (context.Context, error) {
+						return ctx, wantErr
+					},
+				)
+				factory = hks.Chain[any](
+					hks.NewCircuitBreakerHooksFactory[any](cb, hks.NoopHooksFactory[any]{}),
+					newFactory(failing),
+				)
+			)
+			cb.Fail()
+			clock.Advance(time.Second)
+			asserterror.Equal(cb.State(), hks.StateHalfOpen, t)
+
+			_, err := factory.New().BeforeSend(context.Background(), cmocks.NewCmd())
+			asserterror.EqualError(err, wantErr, t)
+			asserterror.Equal(cb.Allow(), true, t)
+		})
+}
+
+func newFactory(hooks mocks.Hooks[any]) *mocks.HooksFactory[any] {
+	factory := mocks.NewHooksFactory[any]()
+	factory.RegisterNew(func() hks.Hooks[any] { return hooks })
+	return factory
+}
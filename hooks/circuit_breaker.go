@@ -1,8 +1,45 @@
 package hooks
 
+import "time"
+
 // CircuitBreaker defines the interface for the Circuit Breaker Pattern.
 type CircuitBreaker interface {
 	Allow() bool
 	Fail()
 	Success()
 }
+
+// TimedCircuitBreaker is a CircuitBreaker that also takes into account the
+// duration of calls, for example, to detect slow ones.
+//
+// If the CircuitBreaker passed to CircuitBreakerHooks implements this
+// interface, FailAfter and SuccessAfter are called instead of Fail and Success.
+type TimedCircuitBreaker interface {
+	CircuitBreaker
+	FailAfter(d time.Duration)
+	SuccessAfter(d time.Duration)
+}
+
+// CallCircuitBreaker is a CircuitBreaker that tracks each allowed call.
+//
+// If the CircuitBreaker passed to CircuitBreakerHooks implements this
+// interface, AllowCall, Done and Release are used instead of the other
+// methods. Outcomes of calls allowed before the last state change are
+// ignored, and calls that were allowed but never made are released.
+type CallCircuitBreaker interface {
+	CircuitBreaker
+	// AllowCall reports whether a call is allowed, and if so, returns the
+	// BreakerCall that identifies it.
+	AllowCall() (call BreakerCall, ok bool)
+	// Done records the outcome of the call.
+	Done(call BreakerCall, failed bool)
+	// Release gives back the call that was allowed but never made.
+	Release(call BreakerCall)
+}
+
+// BreakerCall identifies a call allowed by a CallCircuitBreaker.
+type BreakerCall struct {
+	gen   uint64
+	probe bool
+	start time.Time
+}
@@ -2,6 +2,8 @@ package hooks
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/cmd-stream/core-go"
 )
@@ -28,43 +30,139 @@ func (f CircuitBreakerHooksFactory[T]) New() Hooks[T] {
 func NewCircuitBreakerHooks[T any](cb CircuitBreaker,
 	hooks Hooks[T],
 ) CircuitBreakerHooks[T] {
-	return CircuitBreakerHooks[T]{cb, hooks}
+	return CircuitBreakerHooks[T]{cb, hooks, &circuitBreakerState{}}
 }
 
 // CircuitBreakerHooks checks whether the circuit breaker allows the operation
 // before sending. If not, it returns ErrNotAllowed, otherwise the
 // corresponding method of the inner Hooks is called.
+//
+// If the circuit breaker implements CallCircuitBreaker, each Command is
+// tracked as a BreakerCall, and if the Command is not sent because the inner
+// BeforeSend fails, the call is released. Otherwise, if it implements
+// TimedCircuitBreaker, it also receives the time elapsed since BeforeSend.
+// Each Command is reported once, when it is completed: on the last Result (or
+// an error), OnError or OnTimeout. Commands aborted by a hooks chain (see
+// ErrAborted), and Commands completed without a Result (see NoWaitHooks) are
+// not reported to the circuit breaker, their calls are released.
 type CircuitBreakerHooks[T any] struct {
 	cb    CircuitBreaker
 	hooks Hooks[T]
+	state *circuitBreakerState
+}
+
+type circuitBreakerState struct {
+	call  BreakerCall
+	start time.Time
+	done  bool
 }
 
 func (h CircuitBreakerHooks[T]) BeforeSend(ctx context.Context, cmd core.Cmd[T]) (
 	context.Context, error,
 ) {
-	if !h.cb.Allow() {
+	if !h.allow() {
 		return ctx, ErrNotAllowed
 	}
-	return h.hooks.BeforeSend(ctx, cmd)
+	ctx, err := h.hooks.BeforeSend(ctx, cmd)
+	if err != nil {
+		h.release()
+	}
+	return ctx, err
 }
 
 func (h CircuitBreakerHooks[T]) OnError(ctx context.Context, sentCmd SentCmd[T],
 	err error,
 ) {
-	h.cb.Fail()
+	if errors.Is(err, ErrAborted) {
+		h.release()
+	} else {
+		h.fail()
+	}
 	h.hooks.OnError(ctx, sentCmd, err)
 }
 
 func (h CircuitBreakerHooks[T]) OnResult(ctx context.Context, sentCmd SentCmd[T],
 	recvResult ReceivedResult, err error,
 ) {
-	h.cb.Success()
+	if err != nil || recvResult.Result == nil || recvResult.Result.LastOne() {
+		h.success()
+	}
 	h.hooks.OnResult(ctx, sentCmd, recvResult, err)
 }
 
 func (h CircuitBreakerHooks[T]) OnTimeout(ctx context.Context, sentCmd SentCmd[T],
 	err error,
 ) {
-	h.cb.Fail()
+	h.fail()
 	h.hooks.OnTimeout(ctx, sentCmd, err)
 }
+
+func (h CircuitBreakerHooks[T]) OnWritten(ctx context.Context,
+	sentCmd SentCmd[T],
+) {
+	h.release()
+	OnWritten(h.hooks, ctx, sentCmd)
+}
+
+func (h CircuitBreakerHooks[T]) OnCacheHit(ctx context.Context, cmd core.Cmd[T],
+	hit CacheHit,
+) {
+	OnCacheHit(h.hooks, ctx, cmd, hit)
+}
+
+func (h CircuitBreakerHooks[T]) allow() bool {
+	if cb, ok := h.cb.(CallCircuitBreaker); ok {
+		var allowed bool
+		h.state.call, allowed = cb.AllowCall()
+		return allowed
+	}
+	h.state.start = time.Now()
+	return h.cb.Allow()
+}
+
+func (h CircuitBreakerHooks[T]) release() {
+	if !h.finish() {
+		return
+	}
+	if cb, ok := h.cb.(CallCircuitBreaker); ok {
+		cb.Release(h.state.call)
+	}
+}
+
+func (h CircuitBreakerHooks[T]) fail() {
+	if !h.finish() {
+		return
+	}
+	switch cb := h.cb.(type) {
+	case CallCircuitBreaker:
+		cb.Done(h.state.call, true)
+	case TimedCircuitBreaker:
+		cb.FailAfter(time.Since(h.state.start))
+	default:
+		h.cb.Fail()
+	}
+}
+
+func (h CircuitBreakerHooks[T]) success() {
+	if !h.finish() {
+		return
+	}
+	switch cb := h.cb.(type) {
+	case CallCircuitBreaker:
+		cb.Done(h.state.call, false)
+	case TimedCircuitBreaker:
+		cb.SuccessAfter(time.Since(h.state.start))
+	default:
+		h.cb.Success()
+	}
+}
+
+// finish marks the Command as reported to the circuit breaker, returns false
+// if it already was.
+func (h CircuitBreakerHooks[T]) finish() bool {
+	if h.state.done {
+		return false
+	}
+	h.state.done = true
+	return true
+}
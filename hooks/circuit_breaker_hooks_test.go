@@ -3,6 +3,7 @@ package hooks_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/cmd-stream/core-go"
@@ -79,6 +80,24 @@ func TestCircuitBreakerHooks(t *testing.T) {
 		asserterror.EqualDeep(mok.CheckCalls(mocks), mok.EmptyInfomap, t)
 	})
 
+	t.Run("OnError should not report an aborted Command to the circuit breaker",
+		func(t *testing.T) {
+			var (
+				wantErr    = fmt.Errorf("%w: %w", hks.ErrAborted, hks.ErrRateLimited)
+				cb         = mocks.NewCircuitBreaker()
+				innerHooks = mocks.NewHooks[any]().RegisterOnError(
+					func(ctx context.Context, sentCmd hks.SentCmd[any], err error) {
+						asserterror.EqualError(err, wantErr, t)
+					},
+				)
+				hooks = hks.NewCircuitBreakerHooks(cb, innerHooks)
+				mocks = []*mok.Mock{cb.Mock, innerHooks.Mock}
+			)
+			hooks.OnError(context.Background(), hks.SentCmd[any]{}, wantErr)
+
+			asserterror.EqualDeep(mok.CheckCalls(mocks), mok.EmptyInfomap, t)
+		})
+
 	t.Run("OnResult", func(t *testing.T) {
 		var (
 			wantCtx        = context.Background()
@@ -0,0 +1,15 @@
+package hooks
+
+import "time"
+
+// Clock provides the current time, it allows to control time in tests.
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock is a Clock that returns the system time.
+type SystemClock struct{}
+
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
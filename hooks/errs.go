@@ -5,3 +5,15 @@ import "errors"
 // ErrNotAllowed indicates that sending the Command is not allowed at this
 // time.
 var ErrNotAllowed = errors.New("not allowed")
+
+// ErrRateLimited indicates that sending the Command was rejected by the rate
+// limiter.
+var ErrRateLimited = errors.New("rate limited")
+
+// ErrBulkheadFull indicates that the Bulkhead has no free slots, and the
+// Command could not wait for one.
+var ErrBulkheadFull = errors.New("bulkhead full")
+
+// ErrAborted indicates that BeforeSend of one of the chained hooks failed, and
+// the Command was not sent.
+var ErrAborted = errors.New("aborted")
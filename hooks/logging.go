@@ -0,0 +1,101 @@
+package hooks
+
+import (
+	"context"
+	"log/slog"
+	"math/rand/v2"
+)
+
+// ErrorClass describes where a Command failed.
+type ErrorClass string
+
+const (
+	// ErrorClassSend means the Command could not be sent.
+	ErrorClassSend ErrorClass = "send"
+	// ErrorClassTimeout means the Command timed out or was canceled.
+	ErrorClassTimeout ErrorClass = "timeout"
+	// ErrorClassResult means the Result could not be received.
+	ErrorClassResult ErrorClass = "result"
+)
+
+// Log attribute keys.
+const (
+	LogCmdTypeKey    = "cmd_type"
+	LogCmdKey        = "cmd"
+	LogSeqKey        = "seq"
+	LogSizeKey       = "size"
+	LogResultSeqKey  = "result_seq"
+	LogResultSizeKey = "result_size"
+	LogResultsKey    = "results"
+	LogLatencyKey    = "latency"
+	LogErrorClassKey = "error_class"
+	LogErrorKey      = "error"
+	LogStaleKey      = "stale"
+)
+
+// RedactFn returns the value logged in place of the Command payload.
+type RedactFn func(cmd any) any
+
+// CtxAttrsFn returns request-scoped attributes stored in the ctx.
+type CtxAttrsFn func(ctx context.Context) []slog.Attr
+
+// SamplerFn decides whether a log record of the specified level should be
+// written.
+type SamplerFn func(level slog.Level) bool
+
+// NewLevelSampler creates a SamplerFn that writes records of each level with
+// the specified probability (from 0 to 1). Levels missing from rates are
+// always written.
+func NewLevelSampler(rates map[slog.Level]float64) SamplerFn {
+	return func(level slog.Level) bool {
+		rate, pst := rates[level]
+		return !pst || rand.Float64() < rate
+	}
+}
+
+type LoggingOptions struct {
+	CmdTypeName CmdTypeNameFn
+	Redact      RedactFn
+	CtxAttrs    CtxAttrsFn
+	Sampler     SamplerFn
+	Clock       Clock
+}
+
+type SetLoggingOption func(o *LoggingOptions)
+
+// WithLoggingCmdTypeName sets the function that names Command types. By
+// default, DefaultCmdTypeName is used.
+func WithLoggingCmdTypeName(fn CmdTypeNameFn) SetLoggingOption {
+	return func(o *LoggingOptions) { o.CmdTypeName = fn }
+}
+
+// WithLoggingRedact makes the Command payload to be logged, as returned by
+// the fn. By default, the payload is not logged.
+func WithLoggingRedact(fn RedactFn) SetLoggingOption {
+	return func(o *LoggingOptions) { o.Redact = fn }
+}
+
+// WithLoggingCtxAttrs sets the function that extracts request-scoped
+// attributes from the ctx, they are added to each log record.
+func WithLoggingCtxAttrs(fn CtxAttrsFn) SetLoggingOption {
+	return func(o *LoggingOptions) { o.CtxAttrs = fn }
+}
+
+// WithLoggingSampler sets the sampler of log records. By default, all records
+// are written.
+func WithLoggingSampler(fn SamplerFn) SetLoggingOption {
+	return func(o *LoggingOptions) { o.Sampler = fn }
+}
+
+// WithLoggingClock sets the clock used to measure latency.
+func WithLoggingClock(clock Clock) SetLoggingOption {
+	return func(o *LoggingOptions) { o.Clock = clock }
+}
+
+func ApplyLogging(ops []SetLoggingOption, o *LoggingOptions) {
+	for i := range ops {
+		if ops[i] != nil {
+			ops[i](o)
+		}
+	}
+}
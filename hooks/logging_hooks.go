@@ -0,0 +1,175 @@
+package hooks
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/cmd-stream/core-go"
+)
+
+// NewLoggingHooksFactory creates a new LoggingHooksFactory.
+func NewLoggingHooksFactory[T any](logger *slog.Logger,
+	factory HooksFactory[T], ops ...SetLoggingOption,
+) LoggingHooksFactory[T] {
+	o := LoggingOptions{CmdTypeName: DefaultCmdTypeName, Clock: SystemClock{}}
+	ApplyLogging(ops, &o)
+	return LoggingHooksFactory[T]{logger, o, factory}
+}
+
+// LoggingHooksFactory can be used to create hooks that log sent Commands.
+type LoggingHooksFactory[T any] struct {
+	logger  *slog.Logger
+	options LoggingOptions
+	factory HooksFactory[T]
+}
+
+func (f LoggingHooksFactory[T]) New() Hooks[T] {
+	return NewLoggingHooks(f.logger, f.options, f.factory.New())
+}
+
+// NewLoggingHooks creates a new LoggingHooks.
+func NewLoggingHooks[T any](logger *slog.Logger, options LoggingOptions,
+	hooks Hooks[T],
+) LoggingHooks[T] {
+	return LoggingHooks[T]{logger, options, hooks, &loggingState{}}
+}
+
+// LoggingHooks logs the send lifecycle of the Command:
+//   - BeforeSend, each Result of a multi-result Command - at the Debug level.
+//   - Completion of the Command, with its latency and the number of Results -
+//     at the Info level.
+//   - OnTimeout - at the Warn level.
+//   - OnError, OnResult with an error - at the Error level.
+//
+// Failure records contain the ErrorClass.
+type LoggingHooks[T any] struct {
+	logger  *slog.Logger
+	options LoggingOptions
+	hooks   Hooks[T]
+	state   *loggingState
+}
+
+type loggingState struct {
+	cmdType string
+	start   time.Time
+	count   int
+}
+
+func (h LoggingHooks[T]) BeforeSend(ctx context.Context, cmd core.Cmd[T]) (
+	context.Context, error,
+) {
+	h.state.cmdType = h.options.CmdTypeName(cmd)
+	h.state.start = h.options.Clock.Now()
+	if h.enabled(ctx, slog.LevelDebug) {
+		var attrs []slog.Attr
+		if h.options.Redact != nil {
+			attrs = append(attrs, slog.Any(LogCmdKey, h.options.Redact(cmd)))
+		}
+		h.log(ctx, slog.LevelDebug, "sending command", attrs...)
+	}
+	return h.hooks.BeforeSend(ctx, cmd)
+}
+
+func (h LoggingHooks[T]) OnError(ctx context.Context, sentCmd SentCmd[T],
+	err error,
+) {
+	if h.enabled(ctx, slog.LevelError) {
+		h.log(ctx, slog.LevelError, "failed to send command",
+			slog.Int64(LogSeqKey, int64(sentCmd.Seq)),
+			slog.Int(LogSizeKey, sentCmd.Size),
+			slog.String(LogErrorClassKey, string(ErrorClassSend)),
+			slog.Any(LogErrorKey, err),
+		)
+	}
+	h.hooks.OnError(ctx, sentCmd, err)
+}
+
+func (h LoggingHooks[T]) OnResult(ctx context.Context, sentCmd SentCmd[T],
+	recvResult ReceivedResult, err error,
+) {
+	h.state.count++
+	lastOne := err != nil || recvResult.Result == nil ||
+		recvResult.Result.LastOne()
+	switch {
+	case err != nil:
+		if h.enabled(ctx, slog.LevelError) {
+			h.log(ctx, slog.LevelError, "failed to receive result",
+				slog.Int64(LogSeqKey, int64(sentCmd.Seq)),
+				slog.Int(LogResultsKey, h.state.count-1),
+				slog.String(LogErrorClassKey, string(ErrorClassResult)),
+				slog.Any(LogErrorKey, err),
+			)
+		}
+	case h.state.count > 1 || !lastOne:
+		if h.enabled(ctx, slog.LevelDebug) {
+			h.log(ctx, slog.LevelDebug, "received result",
+				slog.Int64(LogSeqKey, int64(sentCmd.Seq)),
+				slog.Int64(LogResultSeqKey, int64(recvResult.Seq)),
+				slog.Int(LogResultSizeKey, recvResult.Size),
+			)
+		}
+	}
+	if err == nil && lastOne && h.enabled(ctx, slog.LevelInfo) {
+		h.log(ctx, slog.LevelInfo, "command completed",
+			slog.Int64(LogSeqKey, int64(sentCmd.Seq)),
+			slog.Int(LogSizeKey, sentCmd.Size),
+			slog.Int(LogResultsKey, h.state.count),
+			slog.Duration(LogLatencyKey, h.options.Clock.Now().Sub(h.state.start)),
+		)
+	}
+	h.hooks.OnResult(ctx, sentCmd, recvResult, err)
+}
+
+func (h LoggingHooks[T]) OnTimeout(ctx context.Context, sentCmd SentCmd[T],
+	err error,
+) {
+	if h.enabled(ctx, slog.LevelWarn) {
+		h.log(ctx, slog.LevelWarn, "command timed out",
+			slog.Int64(LogSeqKey, int64(sentCmd.Seq)),
+			slog.Int(LogSizeKey, sentCmd.Size),
+			slog.Int(LogResultsKey, h.state.count),
+			slog.Duration(LogLatencyKey, h.options.Clock.Now().Sub(h.state.start)),
+			slog.String(LogErrorClassKey, string(ErrorClassTimeout)),
+			slog.Any(LogErrorKey, err),
+		)
+	}
+	h.hooks.OnTimeout(ctx, sentCmd, err)
+}
+
+func (h LoggingHooks[T]) OnWritten(ctx context.Context, sentCmd SentCmd[T]) {
+	if h.enabled(ctx, slog.LevelDebug) {
+		h.log(ctx, slog.LevelDebug, "command written",
+			slog.Int64(LogSeqKey, int64(sentCmd.Seq)),
+			slog.Int(LogSizeKey, sentCmd.Size),
+		)
+	}
+	OnWritten(h.hooks, ctx, sentCmd)
+}
+
+func (h LoggingHooks[T]) OnCacheHit(ctx context.Context, cmd core.Cmd[T],
+	hit CacheHit,
+) {
+	h.state.cmdType = h.options.CmdTypeName(cmd)
+	if h.enabled(ctx, slog.LevelDebug) {
+		h.log(ctx, slog.LevelDebug, "served from cache",
+			slog.Bool(LogStaleKey, hit.Stale))
+	}
+	OnCacheHit(h.hooks, ctx, cmd, hit)
+}
+
+func (h LoggingHooks[T]) enabled(ctx context.Context, level slog.Level) bool {
+	return h.logger.Enabled(ctx, level) &&
+		(h.options.Sampler == nil || h.options.Sampler(level))
+}
+
+func (h LoggingHooks[T]) log(ctx context.Context, level slog.Level,
+	msg string, attrs ...slog.Attr,
+) {
+	attrs = append([]slog.Attr{slog.String(LogCmdTypeKey, h.state.cmdType)},
+		attrs...)
+	if h.options.CtxAttrs != nil {
+		attrs = append(attrs, h.options.CtxAttrs(ctx)...)
+	}
+	h.logger.LogAttrs(ctx, level, msg, attrs...)
+}
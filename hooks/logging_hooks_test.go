@@ -0,0 +1,122 @@
+package hooks_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	hks "github.com/cmd-stream/sender-go/hooks"
+	"github.com/cmd-stream/sender-go/test/helpers"
+	cmocks "github.com/cmd-stream/testkit-go/mocks/core"
+	asserterror "github.com/ymz-ncnk/assert/error"
+)
+
+type ctxKey struct{}
+
+func TestLoggingHooks(t *testing.T) {
+	t.Run("Should log a multi-result Command", func(t *testing.T) {
+		var (
+			buf     bytes.Buffer
+			clock   = helpers.NewClock(time.Unix(0, 0))
+			factory = hks.NewLoggingHooksFactory[any](newLogger(&buf),
+				hks.NoopHooksFactory[any]{},
+				hks.WithLoggingCmdTypeName(func(cmd any) string { return "MyCmd" }),
+				hks.WithLoggingRedact(func(cmd any) any { return "***" }),
+				hks.WithLoggingCtxAttrs(func(ctx context.Context) []slog.Attr {
+					return []slog.Attr{slog.Any("request_id", ctx.Value(ctxKey{}))}
+				}),
+				hks.WithLoggingClock(clock),
+			)
+			hooks   = factory.New()
+			ctx     = context.WithValue(context.Background(), ctxKey{}, "42")
+			sentCmd = hks.SentCmd[any]{Seq: 1, Size: 10}
+		)
+		ctx, err := hooks.BeforeSend(ctx, cmocks.NewCmd())
+		asserterror.EqualError(err, nil, t)
+		hooks.OnResult(ctx, sentCmd, hks.ReceivedResult{Seq: 1, Size: 3,
+			Result: cmocks.NewResult().RegisterLastOne(func() bool { return false })},
+			nil)
+		clock.Advance(time.Second)
+		hooks.OnResult(ctx, sentCmd, hks.ReceivedResult{Seq: 2, Size: 4,
+			Result: cmocks.NewResult().RegisterLastOne(func() bool { return true })},
+			nil)
+
+		asserterror.EqualDeep(records(&buf, t), []map[string]any{
+			{"level": "DEBUG", "msg": "sending command", "cmd_type": "MyCmd",
+				"cmd": "***", "request_id": "42"},
+			{"level": "DEBUG", "msg": "received result", "cmd_type": "MyCmd",
+				"seq": 1.0, "result_seq": 1.0, "result_size": 3.0, "request_id": "42"},
+			{"level": "DEBUG", "msg": "received result", "cmd_type": "MyCmd",
+				"seq": 1.0, "result_seq": 2.0, "result_size": 4.0, "request_id": "42"},
+			{"level": "INFO", "msg": "command completed", "cmd_type": "MyCmd",
+				"seq": 1.0, "size": 10.0, "results": 2.0, "latency": 1e9,
+				"request_id": "42"},
+		}, t)
+	})
+
+	t.Run("Should log failures with the error class", func(t *testing.T) {
+		var (
+			buf     bytes.Buffer
+			factory = hks.NewLoggingHooksFactory[any](newLogger(&buf),
+				hks.NoopHooksFactory[any]{},
+				hks.WithLoggingSampler(func(level slog.Level) bool {
+					return level != slog.LevelDebug
+				}),
+			)
+			err = errors.New("error")
+		)
+		hooks := factory.New()
+		hooks.BeforeSend(context.Background(), cmocks.NewCmd())
+		hooks.OnError(context.Background(), hks.SentCmd[any]{}, err)
+
+		hooks = factory.New()
+		hooks.BeforeSend(context.Background(), cmocks.NewCmd())
+		hooks.OnTimeout(context.Background(), hks.SentCmd[any]{}, err)
+
+		hooks = factory.New()
+		hooks.BeforeSend(context.Background(), cmocks.NewCmd())
+		hooks.OnResult(context.Background(), hks.SentCmd[any]{},
+			hks.ReceivedResult{}, err)
+
+		var classes []any
+		for _, record := range records(&buf, t) {
+			asserterror.Equal(record["error"], "error", t)
+			classes = append(classes, record["error_class"])
+		}
+		asserterror.EqualDeep(classes, []any{"send", "timeout", "result"}, t)
+	})
+}
+
+func TestLevelSampler(t *testing.T) {
+	sampler := hks.NewLevelSampler(map[slog.Level]float64{slog.LevelDebug: 0})
+	asserterror.Equal(sampler(slog.LevelDebug), false, t)
+	asserterror.Equal(sampler(slog.LevelInfo), true, t)
+}
+
+func newLogger(buf *bytes.Buffer) *slog.Logger {
+	return slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+}
+
+func records(buf *bytes.Buffer, t *testing.T) (records []map[string]any) {
+	dec := json.NewDecoder(buf)
+	for dec.More() {
+		var record map[string]any
+		if err := dec.Decode(&record); err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, record)
+	}
+	return
+}
@@ -0,0 +1,78 @@
+package hooks
+
+import (
+	"reflect"
+	"time"
+)
+
+// MetricsCollector receives metrics from MetricsHooks. It can be implemented
+// on top of any metrics library, for example, with Prometheus counter,
+// histogram and gauge vectors labeled by the Command type.
+type MetricsCollector interface {
+	// IncSent increments the number of sent Commands.
+	IncSent(cmdType string)
+	// IncResults increments the number of received Results.
+	IncResults(cmdType string)
+	// IncErrors increments the number of send and Result errors.
+	IncErrors(cmdType string)
+	// IncTimeouts increments the number of timed out (or canceled) Commands.
+	IncTimeouts(cmdType string)
+	// ObserveLatency observes the time from BeforeSend to the last Result.
+	ObserveLatency(cmdType string, latency time.Duration)
+	// ObserveBytesSent observes the size of the sent Command.
+	ObserveBytesSent(cmdType string, n int)
+	// ObserveBytesReceived observes the size of the received Result.
+	ObserveBytesReceived(cmdType string, n int)
+	// AddInFlight changes the number of in-flight Commands by delta.
+	AddInFlight(cmdType string, delta int)
+}
+
+// CacheHitsCollector is an optional interface of MetricsCollector, used to
+// count cache hits.
+type CacheHitsCollector interface {
+	// IncCacheHits increments the number of Results served from the cache.
+	// Such Commands are not counted by other metrics.
+	IncCacheHits(cmdType string)
+}
+
+// CmdTypeNameFn returns the name of the Command type used as a metrics label.
+type CmdTypeNameFn func(cmd any) string
+
+// DefaultCmdTypeName returns the name of the Command type without the package
+// path, for example, "MyCmd".
+func DefaultCmdTypeName(cmd any) string {
+	t := reflect.TypeOf(cmd)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil {
+		return ""
+	}
+	return t.Name()
+}
+
+type MetricsOptions struct {
+	CmdTypeName CmdTypeNameFn
+	Clock       Clock
+}
+
+type SetMetricsOption func(o *MetricsOptions)
+
+// WithMetricsCmdTypeName sets the function that names Command types. By
+// default, DefaultCmdTypeName is used.
+func WithMetricsCmdTypeName(fn CmdTypeNameFn) SetMetricsOption {
+	return func(o *MetricsOptions) { o.CmdTypeName = fn }
+}
+
+// WithMetricsClock sets the clock used to measure latency.
+func WithMetricsClock(clock Clock) SetMetricsOption {
+	return func(o *MetricsOptions) { o.Clock = clock }
+}
+
+func ApplyMetrics(ops []SetMetricsOption, o *MetricsOptions) {
+	for i := range ops {
+		if ops[i] != nil {
+			ops[i](o)
+		}
+	}
+}
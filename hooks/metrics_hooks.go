@@ -0,0 +1,139 @@
+package hooks
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/cmd-stream/core-go"
+)
+
+// NewMetricsHooksFactory creates a new MetricsHooksFactory.
+func NewMetricsHooksFactory[T any](collector MetricsCollector,
+	factory HooksFactory[T], ops ...SetMetricsOption,
+) MetricsHooksFactory[T] {
+	o := MetricsOptions{CmdTypeName: DefaultCmdTypeName, Clock: SystemClock{}}
+	ApplyMetrics(ops, &o)
+	return MetricsHooksFactory[T]{collector, o, factory}
+}
+
+// MetricsHooksFactory can be used to create hooks that collect metrics of
+// sent Commands.
+type MetricsHooksFactory[T any] struct {
+	collector MetricsCollector
+	options   MetricsOptions
+	factory   HooksFactory[T]
+}
+
+func (f MetricsHooksFactory[T]) New() Hooks[T] {
+	return NewMetricsHooks(f.collector, f.options, f.factory.New())
+}
+
+// NewMetricsHooks creates a new MetricsHooks.
+func NewMetricsHooks[T any](collector MetricsCollector, options MetricsOptions,
+	hooks Hooks[T],
+) MetricsHooks[T] {
+	return MetricsHooks[T]{collector, options, hooks, &metricsState{}}
+}
+
+// MetricsHooks reports metrics of the Command to the MetricsCollector.
+//
+// The Command is counted as in-flight once BeforeSend of the inner Hooks
+// succeeds, and stays in-flight until it is completed: in OnError, OnTimeout,
+// OnWritten, or in OnResult when the last Result (or an error) is received.
+// It is counted as sent on the first of these calls. Commands aborted by a
+// hooks chain (see ErrAborted) are counted neither as sent nor as errors.
+type MetricsHooks[T any] struct {
+	collector MetricsCollector
+	options   MetricsOptions
+	hooks     Hooks[T]
+	state     *metricsState
+}
+
+type metricsState struct {
+	cmdType  string
+	start    time.Time
+	sent     bool
+	observed bool
+}
+
+func (h MetricsHooks[T]) BeforeSend(ctx context.Context, cmd core.Cmd[T]) (
+	actx context.Context, err error,
+) {
+	h.state.start = h.options.Clock.Now()
+	actx, err = h.hooks.BeforeSend(ctx, cmd)
+	if err != nil {
+		return
+	}
+	h.state.cmdType = h.options.CmdTypeName(cmd)
+	h.collector.AddInFlight(h.state.cmdType, 1)
+	return
+}
+
+func (h MetricsHooks[T]) OnError(ctx context.Context, sentCmd SentCmd[T],
+	err error,
+) {
+	if !errors.Is(err, ErrAborted) {
+		h.countSent()
+		h.collector.IncErrors(h.state.cmdType)
+	}
+	h.collector.AddInFlight(h.state.cmdType, -1)
+	h.hooks.OnError(ctx, sentCmd, err)
+}
+
+func (h MetricsHooks[T]) OnResult(ctx context.Context, sentCmd SentCmd[T],
+	recvResult ReceivedResult, err error,
+) {
+	h.observeSent(sentCmd)
+	if err != nil {
+		h.collector.IncErrors(h.state.cmdType)
+	} else {
+		h.collector.IncResults(h.state.cmdType)
+		h.collector.ObserveBytesReceived(h.state.cmdType, recvResult.Size)
+	}
+	if err != nil || recvResult.Result == nil || recvResult.Result.LastOne() {
+		h.collector.ObserveLatency(h.state.cmdType,
+			h.options.Clock.Now().Sub(h.state.start))
+		h.collector.AddInFlight(h.state.cmdType, -1)
+	}
+	h.hooks.OnResult(ctx, sentCmd, recvResult, err)
+}
+
+func (h MetricsHooks[T]) OnTimeout(ctx context.Context, sentCmd SentCmd[T],
+	err error,
+) {
+	h.observeSent(sentCmd)
+	h.collector.IncTimeouts(h.state.cmdType)
+	h.collector.AddInFlight(h.state.cmdType, -1)
+	h.hooks.OnTimeout(ctx, sentCmd, err)
+}
+
+func (h MetricsHooks[T]) OnWritten(ctx context.Context, sentCmd SentCmd[T]) {
+	h.observeSent(sentCmd)
+	h.collector.AddInFlight(h.state.cmdType, -1)
+	OnWritten(h.hooks, ctx, sentCmd)
+}
+
+func (h MetricsHooks[T]) OnCacheHit(ctx context.Context, cmd core.Cmd[T],
+	hit CacheHit,
+) {
+	if c, ok := h.collector.(CacheHitsCollector); ok {
+		c.IncCacheHits(h.options.CmdTypeName(cmd))
+	}
+	OnCacheHit(h.hooks, ctx, cmd, hit)
+}
+
+func (h MetricsHooks[T]) countSent() {
+	if !h.state.sent {
+		h.collector.IncSent(h.state.cmdType)
+		h.state.sent = true
+	}
+}
+
+func (h MetricsHooks[T]) observeSent(sentCmd SentCmd[T]) {
+	h.countSent()
+	if !h.state.observed {
+		h.collector.ObserveBytesSent(h.state.cmdType, sentCmd.Size)
+		h.state.observed = true
+	}
+}
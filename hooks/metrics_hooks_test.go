@@ -0,0 +1,217 @@
+package hooks_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cmd-stream/core-go"
+	hks "github.com/cmd-stream/sender-go/hooks"
+	"github.com/cmd-stream/sender-go/test/helpers"
+	cmocks "github.com/cmd-stream/testkit-go/mocks/core"
+	asserterror "github.com/ymz-ncnk/assert/error"
+	"github.com/ymz-ncnk/mok"
+
+	"github.com/cmd-stream/sender-go/test/mocks"
+)
+
+func TestMetricsHooks(t *testing.T) {
+	cmdTypeName := hks.WithMetricsCmdTypeName(
+		func(cmd any) string { return "MyCmd" },
+	)
+
+	t.Run("Should collect metrics of a multi-result Command", func(t *testing.T) {
+		var (
+			clock     = helpers.NewClock(time.Unix(0, 0))
+			collector = mocks.NewMetricsCollector().RegisterIncSent(
+				func(cmdType string) { asserterror.Equal(cmdType, "MyCmd", t) },
+			).RegisterAddInFlight(
+				func(cmdType string, delta int) { asserterror.Equal(delta, 1, t) },
+			).RegisterObserveBytesSent(
+				func(cmdType string, n int) { asserterror.Equal(n, 10, t) },
+			).RegisterIncResults(
+				func(cmdType string) {},
+			).RegisterObserveBytesReceived(
+				func(cmdType string, n int) { asserterror.Equal(n, 3, t) },
+			).RegisterIncResults(
+				func(cmdType string) {},
+			).RegisterObserveBytesReceived(
+				func(cmdType string, n int) { asserterror.Equal(n, 4, t) },
+			).RegisterObserveLatency(
+				func(cmdType string, latency time.Duration) {
+					asserterror.Equal(latency, time.Second, t)
+				},
+			).RegisterAddInFlight(
+				func(cmdType string, delta int) { asserterror.Equal(delta, -1, t) },
+			)
+			factory = hks.NewMetricsHooksFactory[any](collector,
+				hks.NoopHooksFactory[any]{}, cmdTypeName, hks.WithMetricsClock(clock))
+			hooks   = factory.New()
+			sentCmd = hks.SentCmd[any]{Seq: 1, Size: 10}
+			mocks   = []*mok.Mock{collector.Mock}
+		)
+		ctx, err := hooks.BeforeSend(context.Background(), cmocks.NewCmd())
+		asserterror.EqualError(err, nil, t)
+		hooks.OnResult(ctx, sentCmd, hks.ReceivedResult{Seq: 1, Size: 3,
+			Result: cmocks.NewResult().RegisterLastOne(func() bool { return false })},
+			nil)
+		clock.Advance(time.Second)
+		hooks.OnResult(ctx, sentCmd, hks.ReceivedResult{Seq: 2, Size: 4,
+			Result: cmocks.NewResult().RegisterLastOne(func() bool { return true })},
+			nil)
+
+		asserterror.EqualDeep(mok.CheckCalls(mocks), mok.EmptyInfomap, t)
+	})
+
+	t.Run("Should count errors and timeouts", func(t *testing.T) {
+		var (
+			collector = mocks.NewMetricsCollector().RegisterIncSent(
+				func(cmdType string) {},
+			).RegisterAddInFlight(
+				func(cmdType string, delta int) {},
+			).RegisterIncErrors(
+				func(cmdType string) {},
+			).RegisterAddInFlight(
+				func(cmdType string, delta int) {},
+			).RegisterIncSent(
+				func(cmdType string) {},
+			).RegisterAddInFlight(
+				func(cmdType string, delta int) {},
+			).RegisterObserveBytesSent(
+				func(cmdType string, n int) {},
+			).RegisterIncTimeouts(
+				func(cmdType string) {},
+			).RegisterAddInFlight(
+				func(cmdType string, delta int) {},
+			)
+			factory = hks.NewMetricsHooksFactory[any](collector,
+				hks.NoopHooksFactory[any]{}, cmdTypeName)
+			err   = errors.New("error")
+			mocks = []*mok.Mock{collector.Mock}
+		)
+		hooks := factory.New()
+		ctx, _ := hooks.BeforeSend(context.Background(), cmocks.NewCmd())
+		hooks.OnError(ctx, hks.SentCmd[any]{}, err)
+
+		hooks = factory.New()
+		ctx, _ = hooks.BeforeSend(context.Background(), cmocks.NewCmd())
+		hooks.OnTimeout(ctx, hks.SentCmd[any]{}, err)
+
+		asserterror.EqualDeep(mok.CheckCalls(mocks), mok.EmptyInfomap, t)
+	})
+
+	t.Run("Should complete a written Command without a Result",
+		func(t *testing.T) {
+			var (
+				collector = mocks.NewMetricsCollector().RegisterIncSent(
+					func(cmdType string) {},
+				).RegisterAddInFlight(
+					func(cmdType string, delta int) { asserterror.Equal(delta, 1, t) },
+				).RegisterObserveBytesSent(
+					func(cmdType string, n int) { asserterror.Equal(n, 10, t) },
+				).RegisterAddInFlight(
+					func(cmdType string, delta int) { asserterror.Equal(delta, -1, t) },
+				)
+				factory = hks.NewMetricsHooksFactory[any](collector,
+					hks.NoopHooksFactory[any]{}, cmdTypeName)
+				mocks = []*mok.Mock{collector.Mock}
+			)
+			hooks := factory.New()
+			ctx, _ := hooks.BeforeSend(context.Background(), cmocks.NewCmd())
+			hks.OnWritten(hooks, ctx, hks.SentCmd[any]{Seq: 1, Size: 10})
+
+			asserterror.EqualDeep(mok.CheckCalls(mocks), mok.EmptyInfomap, t)
+		})
+
+	t.Run("Should count cache hits", func(t *testing.T) {
+		var (
+			collector = mocks.NewMetricsCollector().RegisterIncCacheHits(
+				func(cmdType string) { asserterror.Equal(cmdType, "MyCmd", t) },
+			)
+			factory = hks.NewMetricsHooksFactory[any](collector,
+				hks.NoopHooksFactory[any]{}, cmdTypeName)
+			mocks = []*mok.Mock{collector.Mock}
+		)
+		hks.OnCacheHit(factory.New(), context.Background(), cmocks.NewCmd(),
+			hks.CacheHit{Key: "key"})
+
+		asserterror.EqualDeep(mok.CheckCalls(mocks), mok.EmptyInfomap, t)
+	})
+
+	t.Run("Should not count cache hits if the collector does not support it",
+		func(t *testing.T) {
+			var (
+				collector = mocks.NewMetricsCollector()
+				factory   = hks.NewMetricsHooksFactory[any](
+					struct{ hks.MetricsCollector }{collector},
+					hks.NoopHooksFactory[any]{}, cmdTypeName)
+				mocks = []*mok.Mock{collector.Mock}
+			)
+			hks.OnCacheHit(factory.New(), context.Background(), cmocks.NewCmd(),
+				hks.CacheHit{Key: "key"})
+
+			asserterror.EqualDeep(mok.CheckCalls(mocks), mok.EmptyInfomap, t)
+		})
+
+	t.Run("Should not count the Command if BeforeSend fails",
+		func(t *testing.T) {
+			var (
+				wantErr    = errors.New("BeforeSend error")
+				collector  = mocks.NewMetricsCollector()
+				innerHooks = mocks.NewHooks[any]().RegisterBeforeSend(
+					func(ctx context.Context, cmd core.Cmd[any]) (context.Context,
+						error,
+					) {
+						return ctx, wantErr
+					},
+				)
+				hooks = hks.NewMetricsHooks[any](collector, hks.MetricsOptions{
+					CmdTypeName: hks.DefaultCmdTypeName,
+					Clock:       hks.SystemClock{},
+				}, innerHooks)
+				mocks = []*mok.Mock{collector.Mock, innerHooks.Mock}
+			)
+			_, err := hooks.BeforeSend(context.Background(), cmocks.NewCmd())
+			asserterror.EqualError(err, wantErr, t)
+
+			asserterror.EqualDeep(mok.CheckCalls(mocks), mok.EmptyInfomap, t)
+		})
+
+	t.Run("Should not count a Command aborted by a hooks chain",
+		func(t *testing.T) {
+			var (
+				collector = mocks.NewMetricsCollector().RegisterAddInFlight(
+					func(cmdType string, delta int) { asserterror.Equal(delta, 1, t) },
+				).RegisterAddInFlight(
+					func(cmdType string, delta int) { asserterror.Equal(delta, -1, t) },
+				)
+				nextHooks = mocks.NewHooks[any]().RegisterBeforeSend(
+					func(ctx context.Context, cmd core.Cmd[any]) (context.Context,
+						error,
+					) {
+						return ctx, hks.ErrRateLimited
+					},
+				)
+				hooks = hks.ChainHooks[any]{
+					hks.NewMetricsHooks[any](collector, hks.MetricsOptions{
+						CmdTypeName: hks.DefaultCmdTypeName,
+						Clock:       hks.SystemClock{},
+					}, hks.NoopHooks[any]{}),
+					nextHooks,
+				}
+				mocks = []*mok.Mock{collector.Mock, nextHooks.Mock}
+			)
+			_, err := hooks.BeforeSend(context.Background(), cmocks.NewCmd())
+			asserterror.EqualError(err, hks.ErrRateLimited, t)
+
+			asserterror.EqualDeep(mok.CheckCalls(mocks), mok.EmptyInfomap, t)
+		})
+}
+
+func TestDefaultCmdTypeName(t *testing.T) {
+	type MyCmd struct{}
+	asserterror.Equal(hks.DefaultCmdTypeName(MyCmd{}), "MyCmd", t)
+	asserterror.Equal(hks.DefaultCmdTypeName(&MyCmd{}), "MyCmd", t)
+	asserterror.Equal(hks.DefaultCmdTypeName(nil), "", t)
+}
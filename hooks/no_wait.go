@@ -0,0 +1,24 @@
+package hooks
+
+import (
+	"context"
+)
+
+// NoWaitHooks is an optional interface of Hooks. A Command sent with
+// sender.SendNoWait without an error sink receives no Result, so instead of
+// OnResult, OnWritten is called once the Command is written. It completes the
+// Command without a Result, hooks that hold resources until the Command
+// completes, such as BulkheadHooks, release them.
+type NoWaitHooks[T any] interface {
+	OnWritten(ctx context.Context, sentCmd SentCmd[T])
+}
+
+// OnWritten calls hooks.OnWritten if the hooks implement NoWaitHooks. Hooks
+// that wrap other hooks use it to pass the call on.
+func OnWritten[T any](hooks Hooks[T], ctx context.Context,
+	sentCmd SentCmd[T],
+) {
+	if h, ok := hooks.(NoWaitHooks[T]); ok {
+		h.OnWritten(ctx, sentCmd)
+	}
+}
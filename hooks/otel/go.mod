@@ -0,0 +1,24 @@
+module github.com/cmd-stream/sender-go/hooks/otel
+
+go 1.23.0
+
+require (
+	github.com/cmd-stream/core-go v0.0.0-20251102020427-f23e62426486
+	github.com/cmd-stream/sender-go v0.0.0-20261017074202-3fdfbad6f727
+	github.com/cmd-stream/testkit-go v0.0.0-20251102015907-0ae91640601a
+	github.com/ymz-ncnk/assert v0.0.0-20250528151733-c41b2fca7933
+	go.opentelemetry.io/otel v1.34.0
+	go.opentelemetry.io/otel/sdk v1.34.0
+	go.opentelemetry.io/otel/trace v1.34.0
+)
+
+require (
+	github.com/go-logr/logr v1.4.2 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	github.com/ymz-ncnk/mok v0.2.1 // indirect
+	go.opentelemetry.io/auto/sdk v1.1.0 // indirect
+	go.opentelemetry.io/otel/metric v1.34.0 // indirect
+	golang.org/x/exp v0.0.0-20250305212735-054e65f0b394 // indirect
+	golang.org/x/sys v0.29.0 // indirect
+)
@@ -0,0 +1,50 @@
+package otel
+
+import (
+	"fmt"
+
+	gotel "go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// SpanNameFn returns the span name for the Command.
+type SpanNameFn func(cmd any) string
+
+// DefaultSpanName names the span after the Command type.
+func DefaultSpanName(cmd any) string {
+	return fmt.Sprintf("%T", cmd)
+}
+
+type Options struct {
+	TracerProvider trace.TracerProvider
+	SpanName       SpanNameFn
+}
+
+type SetOption func(o *Options)
+
+// WithTracerProvider sets the TracerProvider used to create spans. By default,
+// the global one is used.
+func WithTracerProvider(provider trace.TracerProvider) SetOption {
+	return func(o *Options) { o.TracerProvider = provider }
+}
+
+// WithSpanName sets the function that names spans. By default,
+// DefaultSpanName is used.
+func WithSpanName(fn SpanNameFn) SetOption {
+	return func(o *Options) { o.SpanName = fn }
+}
+
+func Apply(ops []SetOption, o *Options) {
+	for i := range ops {
+		if ops[i] != nil {
+			ops[i](o)
+		}
+	}
+}
+
+func defaultOptions() Options {
+	return Options{
+		TracerProvider: gotel.GetTracerProvider(),
+		SpanName:       DefaultSpanName,
+	}
+}
@@ -0,0 +1,175 @@
+package otel
+
+import (
+	"context"
+
+	"github.com/cmd-stream/core-go"
+	hks "github.com/cmd-stream/sender-go/hooks"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName is the name of the tracer used by TracingHooks.
+const TracerName = "github.com/cmd-stream/sender-go/hooks/otel"
+
+// Span attributes.
+const (
+	CmdSeqKey      = attribute.Key("cmd_stream.cmd.seq")
+	CmdSizeKey     = attribute.Key("cmd_stream.cmd.size")
+	ResultSeqKey   = attribute.Key("cmd_stream.result.seq")
+	ResultSizeKey  = attribute.Key("cmd_stream.result.size")
+	ResultCountKey = attribute.Key("cmd_stream.result.count")
+	CacheHitKey    = attribute.Key("cmd_stream.cache.hit")
+	CacheStaleKey  = attribute.Key("cmd_stream.cache.stale")
+)
+
+// ResultEventName is the name of the span event added per Result.
+const ResultEventName = "result"
+
+// NewTracingHooksFactory creates a new TracingHooksFactory.
+func NewTracingHooksFactory[T any](factory hks.HooksFactory[T],
+	ops ...SetOption,
+) TracingHooksFactory[T] {
+	o := defaultOptions()
+	Apply(ops, &o)
+	return TracingHooksFactory[T]{
+		tracer:   o.TracerProvider.Tracer(TracerName),
+		spanName: o.SpanName,
+		factory:  factory,
+	}
+}
+
+// TracingHooksFactory can be used to create hooks that trace sent Commands
+// with OpenTelemetry.
+type TracingHooksFactory[T any] struct {
+	tracer   trace.Tracer
+	spanName SpanNameFn
+	factory  hks.HooksFactory[T]
+}
+
+func (f TracingHooksFactory[T]) New() hks.Hooks[T] {
+	return NewTracingHooks(f.tracer, f.spanName, f.factory.New())
+}
+
+// NewTracingHooks creates a new TracingHooks.
+func NewTracingHooks[T any](tracer trace.Tracer, spanName SpanNameFn,
+	hooks hks.Hooks[T],
+) TracingHooks[T] {
+	return TracingHooks[T]{tracer, spanName, hooks, &tracingState{}}
+}
+
+// TracingHooks starts a client span in BeforeSend and passes the span-carrying
+// ctx to the inner Hooks. The span ends once the Command is completed: in
+// OnError, OnTimeout, OnWritten, or in OnResult when the last Result (or an
+// error) is received.
+//
+// The span records the Command's Seq and size, and the total size and number
+// of received Results. For multi-result Commands, an event is added per
+// Result. A Result served from the cache gets its own span, which is ended
+// right away.
+type TracingHooks[T any] struct {
+	tracer   trace.Tracer
+	spanName SpanNameFn
+	hooks    hks.Hooks[T]
+	state    *tracingState
+}
+
+type tracingState struct {
+	span  trace.Span
+	count int
+	size  int
+}
+
+func (h TracingHooks[T]) BeforeSend(ctx context.Context, cmd core.Cmd[T]) (
+	context.Context, error,
+) {
+	ctx, h.state.span = h.tracer.Start(ctx, h.spanName(cmd),
+		trace.WithSpanKind(trace.SpanKindClient))
+	actx, err := h.hooks.BeforeSend(ctx, cmd)
+	if err != nil {
+		fail(h.state.span, err)
+		h.state.span.End()
+	}
+	return actx, err
+}
+
+func (h TracingHooks[T]) OnError(ctx context.Context, sentCmd hks.SentCmd[T],
+	err error,
+) {
+	fail(h.state.span, err)
+	h.hooks.OnError(ctx, sentCmd, err)
+	h.end(sentCmd)
+}
+
+func (h TracingHooks[T]) OnResult(ctx context.Context, sentCmd hks.SentCmd[T],
+	recvResult hks.ReceivedResult, err error,
+) {
+	var (
+		span    = h.state.span
+		lastOne = err != nil || recvResult.Result == nil ||
+			recvResult.Result.LastOne()
+	)
+	h.state.count++
+	h.state.size += recvResult.Size
+	if h.state.count > 1 || !lastOne {
+		span.AddEvent(ResultEventName, trace.WithAttributes(
+			ResultSeqKey.Int64(int64(recvResult.Seq)),
+			ResultSizeKey.Int(recvResult.Size),
+		))
+	}
+	if err != nil {
+		fail(span, err)
+	}
+	h.hooks.OnResult(ctx, sentCmd, recvResult, err)
+	if lastOne {
+		h.end(sentCmd)
+	}
+}
+
+func (h TracingHooks[T]) OnTimeout(ctx context.Context, sentCmd hks.SentCmd[T],
+	err error,
+) {
+	fail(h.state.span, err)
+	h.hooks.OnTimeout(ctx, sentCmd, err)
+	h.end(sentCmd)
+}
+
+func (h TracingHooks[T]) OnWritten(ctx context.Context,
+	sentCmd hks.SentCmd[T],
+) {
+	hks.OnWritten(h.hooks, ctx, sentCmd)
+	h.end(sentCmd)
+}
+
+func (h TracingHooks[T]) OnCacheHit(ctx context.Context, cmd core.Cmd[T],
+	hit hks.CacheHit,
+) {
+	ctx, span := h.tracer.Start(ctx, h.spanName(cmd),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		trace.WithAttributes(
+			CacheHitKey.Bool(true),
+			CacheStaleKey.Bool(hit.Stale),
+		))
+	if hit.Err != nil {
+		fail(span, hit.Err)
+	}
+	hks.OnCacheHit(h.hooks, ctx, cmd, hit)
+	span.End()
+}
+
+func (h TracingHooks[T]) end(sentCmd hks.SentCmd[T]) {
+	span := h.state.span
+	span.SetAttributes(
+		CmdSeqKey.Int64(int64(sentCmd.Seq)),
+		CmdSizeKey.Int(sentCmd.Size),
+		ResultSizeKey.Int(h.state.size),
+		ResultCountKey.Int(h.state.count),
+	)
+	span.End()
+}
+
+func fail(span trace.Span, err error) {
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
@@ -0,0 +1,181 @@
+package otel_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cmd-stream/core-go"
+	hks "github.com/cmd-stream/sender-go/hooks"
+	hotel "github.com/cmd-stream/sender-go/hooks/otel"
+	cmocks "github.com/cmd-stream/testkit-go/mocks/core"
+	asserterror "github.com/ymz-ncnk/assert/error"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTracingHooks(t *testing.T) {
+	t.Run("Should record a single Result", func(t *testing.T) {
+		var (
+			exporter = tracetest.NewInMemoryExporter()
+			factory  = newFactory(exporter, hotel.WithSpanName(
+				func(cmd any) string { return "cmd" },
+			))
+			hooks      = factory.New()
+			sentCmd    = hks.SentCmd[any]{Seq: 2, Size: 10}
+			recvResult = hks.ReceivedResult{Seq: 2, Size: 5,
+				Result: cmocks.NewResult().RegisterLastOne(func() bool { return true })}
+		)
+		ctx, err := hooks.BeforeSend(context.Background(), cmocks.NewCmd())
+		asserterror.EqualError(err, nil, t)
+		asserterror.Equal(trace.SpanFromContext(ctx).IsRecording(), true, t)
+
+		hooks.OnResult(ctx, sentCmd, recvResult, nil)
+
+		spans := exporter.GetSpans()
+		asserterror.Equal(len(spans), 1, t)
+		asserterror.Equal(spans[0].Name, "cmd", t)
+		asserterror.Equal(spans[0].SpanKind, trace.SpanKindClient, t)
+		asserterror.Equal(len(spans[0].Events), 0, t)
+		asserterror.EqualDeep(spans[0].Attributes, []attribute.KeyValue{
+			hotel.CmdSeqKey.Int64(2),
+			hotel.CmdSizeKey.Int(10),
+			hotel.ResultSizeKey.Int(5),
+			hotel.ResultCountKey.Int(1),
+		}, t)
+		asserterror.Equal(spans[0].Status.Code, codes.Unset, t)
+	})
+
+	t.Run("Should add an event per Result of a multi-result Command",
+		func(t *testing.T) {
+			var (
+				exporter = tracetest.NewInMemoryExporter()
+				hooks    = newFactory(exporter).New()
+				results  = []core.Result{
+					cmocks.NewResult().RegisterLastOne(func() bool { return false }),
+					cmocks.NewResult().RegisterLastOne(func() bool { return true }),
+				}
+			)
+			ctx, _ := hooks.BeforeSend(context.Background(), cmocks.NewCmd())
+			for i := range results {
+				hooks.OnResult(ctx, hks.SentCmd[any]{Seq: 1}, hks.ReceivedResult{
+					Seq: core.Seq(i + 1), Size: 3, Result: results[i]}, nil)
+				asserterror.Equal(len(exporter.GetSpans()), i, t)
+			}
+			spans := exporter.GetSpans()
+			asserterror.Equal(len(spans[0].Events), 2, t)
+			for i, event := range spans[0].Events {
+				asserterror.Equal(event.Name, hotel.ResultEventName, t)
+				asserterror.EqualDeep(event.Attributes, []attribute.KeyValue{
+					hotel.ResultSeqKey.Int64(int64(i + 1)),
+					hotel.ResultSizeKey.Int(3),
+				}, t)
+			}
+			asserterror.EqualDeep(spans[0].Attributes[2:], []attribute.KeyValue{
+				hotel.ResultSizeKey.Int(6),
+				hotel.ResultCountKey.Int(2),
+			}, t)
+		})
+
+	t.Run("Should end the span if the ctx is replaced", func(t *testing.T) {
+		var (
+			exporter = tracetest.NewInMemoryExporter()
+			hooks    = newFactory(exporter).New()
+		)
+		_, err := hooks.BeforeSend(context.Background(), cmocks.NewCmd())
+		asserterror.EqualError(err, nil, t)
+
+		hooks.OnTimeout(context.Background(), hks.SentCmd[any]{Seq: 3},
+			errors.New("timeout"))
+
+		spans := exporter.GetSpans()
+		asserterror.Equal(len(spans), 1, t)
+		asserterror.Equal(spans[0].Attributes[0], hotel.CmdSeqKey.Int64(3), t)
+		asserterror.Equal(spans[0].Status.Code, codes.Error, t)
+	})
+
+	t.Run("Should end the span once the Command is written",
+		func(t *testing.T) {
+			var (
+				exporter = tracetest.NewInMemoryExporter()
+				hooks    = newFactory(exporter).New()
+			)
+			ctx, _ := hooks.BeforeSend(context.Background(), cmocks.NewCmd())
+			hks.OnWritten(hooks, ctx, hks.SentCmd[any]{Seq: 1, Size: 10})
+
+			spans := exporter.GetSpans()
+			asserterror.Equal(len(spans), 1, t)
+			asserterror.EqualDeep(spans[0].Attributes, []attribute.KeyValue{
+				hotel.CmdSeqKey.Int64(1),
+				hotel.CmdSizeKey.Int(10),
+				hotel.ResultSizeKey.Int(0),
+				hotel.ResultCountKey.Int(0),
+			}, t)
+		})
+
+	t.Run("Should record a cache hit", func(t *testing.T) {
+		var (
+			exporter = tracetest.NewInMemoryExporter()
+			hooks    = newFactory(exporter, hotel.WithSpanName(
+				func(cmd any) string { return "cmd" },
+			)).New()
+		)
+		hks.OnCacheHit(hooks, context.Background(), cmocks.NewCmd(),
+			hks.CacheHit{Key: "key", Stale: true})
+
+		spans := exporter.GetSpans()
+		asserterror.Equal(len(spans), 1, t)
+		asserterror.Equal(spans[0].Name, "cmd", t)
+		asserterror.Equal(spans[0].SpanKind, trace.SpanKindInternal, t)
+		asserterror.EqualDeep(spans[0].Attributes, []attribute.KeyValue{
+			hotel.CacheHitKey.Bool(true),
+			hotel.CacheStaleKey.Bool(true),
+		}, t)
+		asserterror.Equal(spans[0].Status.Code, codes.Unset, t)
+	})
+
+	t.Run("Should set error status", func(t *testing.T) {
+		var (
+			exporter = tracetest.NewInMemoryExporter()
+			factory  = newFactory(exporter)
+			wantErr  = errors.New("error")
+			calls    = []func(hooks hks.Hooks[any], ctx context.Context){
+				func(hooks hks.Hooks[any], ctx context.Context) {
+					hooks.OnError(ctx, hks.SentCmd[any]{}, wantErr)
+				},
+				func(hooks hks.Hooks[any], ctx context.Context) {
+					hooks.OnTimeout(ctx, hks.SentCmd[any]{}, wantErr)
+				},
+				func(hooks hks.Hooks[any], ctx context.Context) {
+					hooks.OnResult(ctx, hks.SentCmd[any]{}, hks.ReceivedResult{},
+						wantErr)
+				},
+			}
+		)
+		for _, call := range calls {
+			hooks := factory.New()
+			ctx, _ := hooks.BeforeSend(context.Background(), cmocks.NewCmd())
+			call(hooks, ctx)
+		}
+		spans := exporter.GetSpans()
+		asserterror.Equal(len(spans), len(calls), t)
+		for _, span := range spans {
+			asserterror.EqualDeep(span.Status, sdktrace.Status{
+				Code:        codes.Error,
+				Description: wantErr.Error(),
+			}, t)
+			asserterror.Equal(span.Events[len(span.Events)-1].Name, "exception", t)
+		}
+	})
+}
+
+func newFactory(exporter *tracetest.InMemoryExporter,
+	ops ...hotel.SetOption,
+) hotel.TracingHooksFactory[any] {
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	ops = append([]hotel.SetOption{hotel.WithTracerProvider(provider)}, ops...)
+	return hotel.NewTracingHooksFactory[any](hks.NoopHooksFactory[any]{}, ops...)
+}
@@ -0,0 +1,99 @@
+package hooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/cmd-stream/core-go"
+)
+
+// RateLimitMode defines how RateLimitHooks behave when the limit is reached.
+type RateLimitMode int
+
+const (
+	// RateLimitWait makes BeforeSend to wait (using the ctx) until the limiter
+	// allows sending.
+	RateLimitWait RateLimitMode = iota
+	// RateLimitFailFast makes BeforeSend to return ErrRateLimited immediately.
+	RateLimitFailFast
+)
+
+// NewRateLimitHooksFactory creates a new RateLimitHooksFactory.
+func NewRateLimitHooksFactory[T any](limiter RateLimiter, mode RateLimitMode,
+	factory HooksFactory[T],
+) RateLimitHooksFactory[T] {
+	return RateLimitHooksFactory[T]{limiter, mode, factory}
+}
+
+// RateLimitHooksFactory can be used to create hooks that limit the rate of
+// sent Commands.
+type RateLimitHooksFactory[T any] struct {
+	limiter RateLimiter
+	mode    RateLimitMode
+	factory HooksFactory[T]
+}
+
+func (f RateLimitHooksFactory[T]) New() Hooks[T] {
+	return NewRateLimitHooks(f.limiter, f.mode, f.factory.New())
+}
+
+// NewRateLimitHooks creates a new RateLimitHooks.
+func NewRateLimitHooks[T any](limiter RateLimiter, mode RateLimitMode,
+	hooks Hooks[T],
+) RateLimitHooks[T] {
+	return RateLimitHooks[T]{limiter, mode, hooks}
+}
+
+// RateLimitHooks checks whether the rate limiter allows sending before
+// calling BeforeSend of the inner Hooks. If not, it returns an error that
+// wraps ErrRateLimited (in the RateLimitWait mode, also the ctx error).
+type RateLimitHooks[T any] struct {
+	limiter RateLimiter
+	mode    RateLimitMode
+	hooks   Hooks[T]
+}
+
+func (h RateLimitHooks[T]) BeforeSend(ctx context.Context, cmd core.Cmd[T]) (
+	context.Context, error,
+) {
+	if h.mode == RateLimitFailFast {
+		if !h.limiter.Allow() {
+			return ctx, ErrRateLimited
+		}
+	} else if err := h.limiter.Wait(ctx); err != nil {
+		if !errors.Is(err, ErrRateLimited) {
+			err = fmt.Errorf("%w: %w", ErrRateLimited, err)
+		}
+		return ctx, err
+	}
+	return h.hooks.BeforeSend(ctx, cmd)
+}
+
+func (h RateLimitHooks[T]) OnError(ctx context.Context, sentCmd SentCmd[T],
+	err error,
+) {
+	h.hooks.OnError(ctx, sentCmd, err)
+}
+
+func (h RateLimitHooks[T]) OnResult(ctx context.Context, sentCmd SentCmd[T],
+	recvResult ReceivedResult, err error,
+) {
+	h.hooks.OnResult(ctx, sentCmd, recvResult, err)
+}
+
+func (h RateLimitHooks[T]) OnTimeout(ctx context.Context, sentCmd SentCmd[T],
+	err error,
+) {
+	h.hooks.OnTimeout(ctx, sentCmd, err)
+}
+
+func (h RateLimitHooks[T]) OnWritten(ctx context.Context, sentCmd SentCmd[T]) {
+	OnWritten(h.hooks, ctx, sentCmd)
+}
+
+func (h RateLimitHooks[T]) OnCacheHit(ctx context.Context, cmd core.Cmd[T],
+	hit CacheHit,
+) {
+	OnCacheHit(h.hooks, ctx, cmd, hit)
+}
@@ -0,0 +1,109 @@
+package hooks_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cmd-stream/core-go"
+	hks "github.com/cmd-stream/sender-go/hooks"
+	cmocks "github.com/cmd-stream/testkit-go/mocks/core"
+	asserterror "github.com/ymz-ncnk/assert/error"
+	"github.com/ymz-ncnk/mok"
+
+	"github.com/cmd-stream/sender-go/test/mocks"
+)
+
+func TestRateLimitHooks(t *testing.T) {
+	t.Run("BeforeSend", func(t *testing.T) {
+		t.Run("Should wait for the limiter", func(t *testing.T) {
+			var (
+				wantCtx = context.Background()
+				limiter = mocks.NewRateLimiter().RegisterWait(
+					func(ctx context.Context) error {
+						asserterror.Equal(ctx, wantCtx, t)
+						return nil
+					},
+				)
+				innerHooks = mocks.NewHooks[any]().RegisterBeforeSend(
+					func(ctx context.Context, cmd core.Cmd[any]) (context.Context, error) {
+						return ctx, nil
+					},
+				)
+				hooks = hks.NewRateLimitHooks(limiter, hks.RateLimitWait, innerHooks)
+				mocks = []*mok.Mock{limiter.Mock, innerHooks.Mock}
+			)
+			ctx, err := hooks.BeforeSend(wantCtx, cmocks.NewCmd())
+			asserterror.Equal(ctx, wantCtx, t)
+			asserterror.EqualError(err, nil, t)
+
+			asserterror.EqualDeep(mok.CheckCalls(mocks), mok.EmptyInfomap, t)
+		})
+
+		t.Run("Should wrap the Wait error with ErrRateLimited", func(t *testing.T) {
+			var (
+				limiter = mocks.NewRateLimiter().RegisterWait(
+					func(ctx context.Context) error { return context.Canceled },
+				)
+				hooks = hks.NewRateLimitHooks[any](limiter, hks.RateLimitWait, nil)
+				mocks = []*mok.Mock{limiter.Mock}
+			)
+			_, err := hooks.BeforeSend(context.Background(), cmocks.NewCmd())
+			asserterror.Equal(errors.Is(err, hks.ErrRateLimited), true, t)
+			asserterror.Equal(errors.Is(err, context.Canceled), true, t)
+
+			asserterror.EqualDeep(mok.CheckCalls(mocks), mok.EmptyInfomap, t)
+		})
+
+		t.Run("Should fail fast if the limiter does not allow", func(t *testing.T) {
+			var (
+				limiter = mocks.NewRateLimiter().RegisterAllow(
+					func() bool { return false },
+				)
+				hooks = hks.NewRateLimitHooks[any](limiter, hks.RateLimitFailFast,
+					nil)
+				mocks = []*mok.Mock{limiter.Mock}
+			)
+			_, err := hooks.BeforeSend(context.Background(), cmocks.NewCmd())
+			asserterror.EqualError(err, hks.ErrRateLimited, t)
+
+			asserterror.EqualDeep(mok.CheckCalls(mocks), mok.EmptyInfomap, t)
+		})
+	})
+
+	t.Run("Should delegate to the inner hooks", func(t *testing.T) {
+		var (
+			wantCtx     = context.Background()
+			wantSentCmd = hks.SentCmd[any]{Seq: 1}
+			wantErr     = errors.New("error")
+			innerHooks  = mocks.NewHooks[any]().RegisterOnError(
+				func(ctx context.Context, sentCmd hks.SentCmd[any], err error) {
+					asserterror.EqualDeep(sentCmd, wantSentCmd, t)
+				},
+			).RegisterOnResult(
+				func(ctx context.Context, sentCmd hks.SentCmd[any],
+					recvResult hks.ReceivedResult, err error,
+				) {
+					asserterror.EqualDeep(sentCmd, wantSentCmd, t)
+				},
+			).RegisterOnTimeout(
+				func(ctx context.Context, sentCmd hks.SentCmd[any], err error) {
+					asserterror.EqualDeep(sentCmd, wantSentCmd, t)
+				},
+			)
+			factory = hks.NewRateLimitHooksFactory(mocks.NewRateLimiter(),
+				hks.RateLimitWait,
+				mocks.NewHooksFactory[any]().RegisterNew(
+					func() hks.Hooks[any] { return innerHooks },
+				),
+			)
+			mocks = []*mok.Mock{innerHooks.Mock}
+		)
+		hooks := factory.New()
+		hooks.OnError(wantCtx, wantSentCmd, wantErr)
+		hooks.OnResult(wantCtx, wantSentCmd, hks.ReceivedResult{}, nil)
+		hooks.OnTimeout(wantCtx, wantSentCmd, wantErr)
+
+		asserterror.EqualDeep(mok.CheckCalls(mocks), mok.EmptyInfomap, t)
+	})
+}
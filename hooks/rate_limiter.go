@@ -0,0 +1,266 @@
+package hooks
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/cmd-stream/sender-go/internal/wait"
+)
+
+// RateLimiter limits the rate of events.
+type RateLimiter interface {
+	// Allow reports whether an event may happen now, without waiting.
+	Allow() bool
+	// Wait blocks until an event may happen or the ctx is done.
+	Wait(ctx context.Context) error
+}
+
+type RateLimiterOptions struct {
+	Clock Clock
+}
+
+type SetRateLimiterOption func(o *RateLimiterOptions)
+
+// WithRateLimiterClock sets the clock used by the rate limiter.
+func WithRateLimiterClock(clock Clock) SetRateLimiterOption {
+	return func(o *RateLimiterOptions) { o.Clock = clock }
+}
+
+func ApplyRateLimiter(ops []SetRateLimiterOption, o *RateLimiterOptions) {
+	for i := range ops {
+		if ops[i] != nil {
+			ops[i](o)
+		}
+	}
+}
+
+// NewTokenBucket creates a new TokenBucket, which is initially full.
+func NewTokenBucket(rate float64, burst int,
+	ops ...SetRateLimiterOption,
+) *TokenBucket {
+	o := RateLimiterOptions{Clock: SystemClock{}}
+	ApplyRateLimiter(ops, &o)
+	return &TokenBucket{
+		clock:   o.Clock,
+		rate:    rate,
+		burst:   burst,
+		tokens:  float64(burst),
+		last:    o.Clock.Now(),
+		changed: make(chan struct{}),
+	}
+}
+
+// TokenBucket is a RateLimiter that refills with rate tokens per second up to
+// burst tokens, each event takes one token. It allows short bursts while
+// keeping the average rate.
+//
+// The rate and burst can be changed at runtime, TokenBucket is safe for
+// concurrent use.
+type TokenBucket struct {
+	mu     sync.Mutex
+	clock  Clock
+	rate   float64
+	burst  int
+	tokens float64
+	// added is the total number of added tokens, waiters use it to know when
+	// their token is added.
+	added   float64
+	last    time.Time
+	changed chan struct{}
+}
+
+// SetRate sets the number of tokens added per second. Callers waiting for a
+// token follow the new rate.
+func (b *TokenBucket) SetRate(rate float64) {
+	b.mu.Lock()
+	b.refill(b.clock.Now())
+	b.rate = rate
+	b.notify()
+	b.mu.Unlock()
+}
+
+// SetBurst sets the bucket capacity.
+func (b *TokenBucket) SetBurst(burst int) {
+	b.mu.Lock()
+	b.refill(b.clock.Now())
+	b.burst = burst
+	b.tokens = math.Min(b.tokens, float64(burst))
+	b.notify()
+	b.mu.Unlock()
+}
+
+func (b *TokenBucket) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill(b.clock.Now())
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// Wait takes a token, if there is none, it waits for one to be added, following
+// changes of the rate and burst. If the ctx is done first, the token is
+// returned to the bucket and the ctx error is returned. If there is no token
+// and the rate is not positive, ErrRateLimited is returned.
+func (b *TokenBucket) Wait(ctx context.Context) (err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill(b.clock.Now())
+	b.tokens--
+	target := b.added - b.tokens
+	for {
+		deficit := target - b.added
+		if deficit <= 0 {
+			return
+		}
+		if b.rate <= 0 {
+			b.putBack()
+			return ErrRateLimited
+		}
+		var (
+			d       = time.Duration(deficit / b.rate * float64(time.Second))
+			timer   = time.NewTimer(d)
+			changed = b.changed
+		)
+		b.mu.Unlock()
+		select {
+		case <-ctx.Done():
+			err = ctx.Err()
+		case <-timer.C:
+		case <-changed:
+		}
+		timer.Stop()
+		b.mu.Lock()
+		if err != nil {
+			b.putBack()
+			return
+		}
+		select {
+		case <-changed:
+			b.refill(b.clock.Now())
+		default:
+			return
+		}
+	}
+}
+
+func (b *TokenBucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		tokens := math.Min(b.tokens+elapsed.Seconds()*b.rate, float64(b.burst))
+		b.added += tokens - b.tokens
+		b.tokens = tokens
+		b.last = now
+	}
+}
+
+// putBack returns the token taken by a waiter that gave up.
+func (b *TokenBucket) putBack() {
+	b.tokens = math.Min(b.tokens+1, float64(b.burst))
+}
+
+// notify wakes up waiters, so they take into account changes of the rate or
+// burst.
+func (b *TokenBucket) notify() {
+	close(b.changed)
+	b.changed = make(chan struct{})
+}
+
+// NewLeakyBucket creates a new LeakyBucket.
+func NewLeakyBucket(rate float64, capacity int,
+	ops ...SetRateLimiterOption,
+) *LeakyBucket {
+	o := RateLimiterOptions{Clock: SystemClock{}}
+	ApplyRateLimiter(ops, &o)
+	return &LeakyBucket{
+		clock:    o.Clock,
+		rate:     rate,
+		interval: interval(rate),
+		capacity: capacity,
+	}
+}
+
+// LeakyBucket is a RateLimiter that lets events through evenly spaced, at
+// most rate events per second, without bursts. Wait queues up to capacity
+// events, if the queue is full, it returns ErrRateLimited. If the rate is not
+// positive, no events are allowed.
+//
+// The rate and capacity can be changed at runtime, LeakyBucket is safe for
+// concurrent use.
+type LeakyBucket struct {
+	mu       sync.Mutex
+	clock    Clock
+	rate     float64
+	interval time.Duration
+	capacity int
+	next     time.Time
+}
+
+// SetRate sets the number of events per second.
+func (b *LeakyBucket) SetRate(rate float64) {
+	b.mu.Lock()
+	b.rate = rate
+	b.interval = interval(rate)
+	b.mu.Unlock()
+}
+
+// SetCapacity sets the maximum number of queued events.
+func (b *LeakyBucket) SetCapacity(capacity int) {
+	b.mu.Lock()
+	b.capacity = capacity
+	b.mu.Unlock()
+}
+
+func (b *LeakyBucket) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := b.clock.Now()
+	if b.rate <= 0 || now.Before(b.next) {
+		return false
+	}
+	b.next = now.Add(b.interval)
+	return true
+}
+
+// Wait takes the next free slot and waits for it. If the ctx is done first,
+// the ctx error is returned. If the rate is not positive, ErrRateLimited is
+// returned immediately.
+func (b *LeakyBucket) Wait(ctx context.Context) error {
+	b.mu.Lock()
+	if b.rate <= 0 {
+		b.mu.Unlock()
+		return ErrRateLimited
+	}
+	var (
+		now  = b.clock.Now()
+		slot = b.next
+	)
+	if slot.Before(now) {
+		slot = now
+	}
+	if slot.Sub(now) > time.Duration(b.capacity)*b.interval {
+		b.mu.Unlock()
+		return ErrRateLimited
+	}
+	b.next = slot.Add(b.interval)
+	b.mu.Unlock()
+	if wait.For(ctx, slot.Sub(now)) {
+		return nil
+	}
+	b.mu.Lock()
+	if b.next.Equal(slot.Add(b.interval)) {
+		b.next = slot
+	}
+	b.mu.Unlock()
+	return ctx.Err()
+}
+
+func interval(rate float64) time.Duration {
+	if rate <= 0 {
+		return 0
+	}
+	return time.Duration(float64(time.Second) / rate)
+}
@@ -0,0 +1,152 @@
+package hooks_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	hks "github.com/cmd-stream/sender-go/hooks"
+	"github.com/cmd-stream/sender-go/test/helpers"
+	asserterror "github.com/ymz-ncnk/assert/error"
+)
+
+func TestTokenBucket(t *testing.T) {
+	t.Run("Should allow bursts and refill", func(t *testing.T) {
+		var (
+			clock = helpers.NewClock(time.Unix(0, 0))
+			b     = hks.NewTokenBucket(2, 2, hks.WithRateLimiterClock(clock))
+		)
+		asserterror.Equal(b.Allow(), true, t)
+		asserterror.Equal(b.Allow(), true, t)
+		asserterror.Equal(b.Allow(), false, t)
+		clock.Advance(500 * time.Millisecond)
+		asserterror.Equal(b.Allow(), true, t)
+		asserterror.Equal(b.Allow(), false, t)
+		clock.Advance(time.Hour)
+		asserterror.Equal(b.Allow(), true, t)
+		asserterror.Equal(b.Allow(), true, t)
+		asserterror.Equal(b.Allow(), false, t)
+	})
+
+	t.Run("Limits should be adjustable", func(t *testing.T) {
+		var (
+			clock = helpers.NewClock(time.Unix(0, 0))
+			b     = hks.NewTokenBucket(1, 1, hks.WithRateLimiterClock(clock))
+		)
+		asserterror.Equal(b.Allow(), true, t)
+		b.SetRate(10)
+		b.SetBurst(3)
+		clock.Advance(time.Second)
+		for range 3 {
+			asserterror.Equal(b.Allow(), true, t)
+		}
+		asserterror.Equal(b.Allow(), false, t)
+	})
+
+	t.Run("Wait should wait for a token", func(t *testing.T) {
+		b := hks.NewTokenBucket(100, 1)
+		asserterror.EqualError(b.Wait(context.Background()), nil, t)
+		start := time.Now()
+		asserterror.EqualError(b.Wait(context.Background()), nil, t)
+		if time.Since(start) < 5*time.Millisecond {
+			t.Error("Wait did not wait")
+		}
+	})
+
+	t.Run("Wait should return the ctx error", func(t *testing.T) {
+		var (
+			b           = hks.NewTokenBucket(1, 0)
+			ctx, cancel = context.WithCancel(context.Background())
+		)
+		cancel()
+		asserterror.EqualError(b.Wait(ctx), context.Canceled, t)
+	})
+
+	t.Run("Wait should return ErrRateLimited if the rate is not positive",
+		func(t *testing.T) {
+			b := hks.NewTokenBucket(0, 1)
+			asserterror.EqualError(b.Wait(context.Background()), nil, t)
+			asserterror.EqualError(b.Wait(context.Background()), hks.ErrRateLimited,
+				t)
+		})
+
+	t.Run("Wait should follow the rate changes", func(t *testing.T) {
+		var (
+			b    = hks.NewTokenBucket(0.001, 1)
+			errs = make(chan error, 1)
+		)
+		asserterror.EqualError(b.Wait(context.Background()), nil, t)
+		go func() { errs <- b.Wait(context.Background()) }()
+		time.Sleep(10 * time.Millisecond)
+		b.SetRate(1000)
+		select {
+		case err := <-errs:
+			asserterror.EqualError(err, nil, t)
+		case <-time.After(time.Second):
+			t.Fatal("Wait did not follow the rate")
+		}
+	})
+
+	t.Run("Wait should return ErrRateLimited once the rate is not positive",
+		func(t *testing.T) {
+			var (
+				b    = hks.NewTokenBucket(0.001, 1)
+				errs = make(chan error, 1)
+			)
+			asserterror.EqualError(b.Wait(context.Background()), nil, t)
+			go func() { errs <- b.Wait(context.Background()) }()
+			time.Sleep(10 * time.Millisecond)
+			b.SetRate(0)
+			asserterror.EqualError(<-errs, hks.ErrRateLimited, t)
+			b.SetRate(1000)
+			time.Sleep(10 * time.Millisecond)
+			asserterror.Equal(b.Allow(), true, t)
+		})
+}
+
+func TestLeakyBucket(t *testing.T) {
+	t.Run("Should space events evenly", func(t *testing.T) {
+		var (
+			clock = helpers.NewClock(time.Unix(0, 0))
+			b     = hks.NewLeakyBucket(10, 1, hks.WithRateLimiterClock(clock))
+		)
+		asserterror.Equal(b.Allow(), true, t)
+		asserterror.Equal(b.Allow(), false, t)
+		clock.Advance(100 * time.Millisecond)
+		asserterror.Equal(b.Allow(), true, t)
+		b.SetRate(1)
+		clock.Advance(100 * time.Millisecond)
+		asserterror.Equal(b.Allow(), true, t)
+		clock.Advance(100 * time.Millisecond)
+		asserterror.Equal(b.Allow(), false, t)
+	})
+
+	t.Run("Wait should reject if the queue is full", func(t *testing.T) {
+		var (
+			clock = helpers.NewClock(time.Unix(0, 0))
+			b     = hks.NewLeakyBucket(1000, 1, hks.WithRateLimiterClock(clock))
+			ctx   = context.Background()
+		)
+		asserterror.EqualError(b.Wait(ctx), nil, t)
+		asserterror.EqualError(b.Wait(ctx), nil, t)
+		asserterror.EqualError(b.Wait(ctx), hks.ErrRateLimited, t)
+		b.SetCapacity(2)
+		asserterror.EqualError(b.Wait(ctx), nil, t)
+	})
+
+	t.Run("Should deny all events if the rate is not positive",
+		func(t *testing.T) {
+			var (
+				clock = helpers.NewClock(time.Unix(0, 0))
+				b     = hks.NewLeakyBucket(0, 10, hks.WithRateLimiterClock(clock))
+				ctx   = context.Background()
+			)
+			asserterror.Equal(b.Allow(), false, t)
+			asserterror.EqualError(b.Wait(ctx), hks.ErrRateLimited, t)
+			b.SetRate(-1)
+			clock.Advance(time.Hour)
+			asserterror.Equal(b.Allow(), false, t)
+			b.SetRate(10)
+			asserterror.Equal(b.Allow(), true, t)
+		})
+}
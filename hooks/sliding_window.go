@@ -0,0 +1,122 @@
+package hooks
+
+import "time"
+
+// window keeps outcomes of the recent calls.
+type window interface {
+	record(now time.Time, failed, slow bool)
+	stats(now time.Time) (total, failures, slows int)
+	reset()
+}
+
+type outcome struct {
+	failed bool
+	slow   bool
+}
+
+func newCountWindow(size int) *countWindow {
+	if size < 1 {
+		size = 1
+	}
+	return &countWindow{outcomes: make([]outcome, size)}
+}
+
+// countWindow keeps outcomes of the last N calls in a ring buffer.
+type countWindow struct {
+	outcomes []outcome
+	next     int
+	total    int
+	failures int
+	slows    int
+}
+
+func (w *countWindow) record(now time.Time, failed, slow bool) {
+	if w.total == len(w.outcomes) {
+		old := w.outcomes[w.next]
+		if old.failed {
+			w.failures--
+		}
+		if old.slow {
+			w.slows--
+		}
+	} else {
+		w.total++
+	}
+	w.outcomes[w.next] = outcome{failed, slow}
+	if failed {
+		w.failures++
+	}
+	if slow {
+		w.slows++
+	}
+	w.next = (w.next + 1) % len(w.outcomes)
+}
+
+func (w *countWindow) stats(now time.Time) (total, failures, slows int) {
+	return w.total, w.failures, w.slows
+}
+
+func (w *countWindow) reset() {
+	w.next, w.total, w.failures, w.slows = 0, 0, 0, 0
+}
+
+type bucket struct {
+	epoch    int64
+	total    int
+	failures int
+	slows    int
+}
+
+func newTimeWindow(d time.Duration, bucketsCount int) *timeWindow {
+	if bucketsCount < 1 {
+		bucketsCount = 1
+	}
+	width := d / time.Duration(bucketsCount)
+	if width <= 0 {
+		width = 1
+	}
+	return &timeWindow{buckets: make([]bucket, bucketsCount), width: width}
+}
+
+// timeWindow keeps outcomes of the calls made during the last window
+// duration. The duration is split into buckets, the oldest bucket is reused
+// once it falls out of the window.
+type timeWindow struct {
+	buckets []bucket
+	width   time.Duration
+}
+
+func (w *timeWindow) record(now time.Time, failed, slow bool) {
+	var (
+		epoch = now.UnixNano() / int64(w.width)
+		b     = &w.buckets[epoch%int64(len(w.buckets))]
+	)
+	if b.epoch != epoch {
+		*b = bucket{epoch: epoch}
+	}
+	b.total++
+	if failed {
+		b.failures++
+	}
+	if slow {
+		b.slows++
+	}
+}
+
+func (w *timeWindow) stats(now time.Time) (total, failures, slows int) {
+	epoch := now.UnixNano() / int64(w.width)
+	for i := range w.buckets {
+		b := w.buckets[i]
+		if b.total == 0 || epoch-b.epoch >= int64(len(w.buckets)) {
+			continue
+		}
+		total += b.total
+		failures += b.failures
+		slows += b.slows
+	}
+	return
+}
+
+func (w *timeWindow) reset() {
+	clear(w.buckets)
+}
@@ -0,0 +1,266 @@
+package hooks
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// BreakerState represents the state of the SlidingWindowBreaker.
+type BreakerState int
+
+const (
+	// StateClosed allows all calls and records their outcomes.
+	StateClosed BreakerState = iota
+	// StateOpen rejects all calls until the open duration expires.
+	StateOpen
+	// StateHalfOpen allows a limited number of probe calls, their outcomes
+	// decide whether the breaker closes or opens again.
+	StateHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half-open"
+	default:
+		return fmt.Sprintf("BreakerState(%d)", int(s))
+	}
+}
+
+// StateChangeFn is called when the SlidingWindowBreaker changes its state.
+type StateChangeFn func(from, to BreakerState)
+
+// NewSlidingWindowBreaker creates a new SlidingWindowBreaker.
+//
+// By default, it uses a count-based window of 100 calls, opens when at least
+// 50% of at least 10 recorded calls have failed, stays open for 5 seconds, and
+// then allows 5 probe calls. Slow call detection is disabled by default.
+func NewSlidingWindowBreaker(ops ...SetBreakerOption) *SlidingWindowBreaker {
+	o := BreakerOptions{
+		WindowType:           CountBasedWindow,
+		WindowSize:           100,
+		BucketsCount:         10,
+		MinCalls:             10,
+		FailureRateThreshold: 0.5,
+		OpenDuration:         5 * time.Second,
+		HalfOpenProbes:       5,
+		Clock:                SystemClock{},
+	}
+	ApplyBreaker(ops, &o)
+	var w window
+	if o.WindowType == TimeBasedWindow {
+		w = newTimeWindow(o.WindowDuration, o.BucketsCount)
+	} else {
+		w = newCountWindow(o.WindowSize)
+	}
+	return &SlidingWindowBreaker{options: o, window: w}
+}
+
+// SlidingWindowBreaker is a CircuitBreaker that keeps outcomes of the recent
+// calls in a sliding window (count-based or time-based) and opens when the
+// failure rate or the slow call rate reaches the configured threshold.
+//
+// After the open duration expires, the breaker becomes half-open and allows
+// a fixed number of probe calls. If their failure and slow call rates are
+// below the thresholds it closes, otherwise it opens again.
+//
+// It implements the TimedCircuitBreaker and CallCircuitBreaker interfaces and
+// is safe for concurrent use. Calls are timed with the configured Clock.
+type SlidingWindowBreaker struct {
+	options BreakerOptions
+	window  window
+
+	mu       sync.Mutex
+	state    BreakerState
+	gen      uint64
+	openedAt time.Time
+	probes   int
+	probed   int
+	probeErr int
+	probeSlw int
+}
+
+// State returns the current state of the breaker.
+func (b *SlidingWindowBreaker) State() BreakerState {
+	b.mu.Lock()
+	from, to := b.refresh(b.options.Clock.Now())
+	state := b.state
+	b.mu.Unlock()
+	b.notify(from, to)
+	return state
+}
+
+// Allow reports whether a call is allowed. In the half-open state each
+// allowed call is considered a probe.
+func (b *SlidingWindowBreaker) Allow() (ok bool) {
+	_, ok = b.AllowCall()
+	return
+}
+
+// AllowCall is like Allow, but also returns the BreakerCall, which should be
+// passed to Done or Release.
+func (b *SlidingWindowBreaker) AllowCall() (call BreakerCall, ok bool) {
+	b.mu.Lock()
+	var (
+		now      = b.options.Clock.Now()
+		from, to = b.refresh(now)
+	)
+	switch b.state {
+	case StateClosed:
+		ok = true
+	case StateHalfOpen:
+		if b.probes < b.options.HalfOpenProbes {
+			b.probes++
+			ok = true
+		}
+	}
+	call = BreakerCall{gen: b.gen, probe: b.state == StateHalfOpen, start: now}
+	b.mu.Unlock()
+	b.notify(from, to)
+	return
+}
+
+// Done records the outcome of the call, the call is slow if it took at least
+// the slow call duration. If the breaker has changed its state since the call
+// was allowed, the outcome is ignored.
+func (b *SlidingWindowBreaker) Done(call BreakerCall, failed bool) {
+	b.mu.Lock()
+	var (
+		now      = b.options.Clock.Now()
+		from, to = b.refresh(now)
+	)
+	if call.gen == b.gen {
+		slow := b.slow(now.Sub(call.start))
+		if f, t, changed := b.apply(now, failed, slow); changed {
+			from, to = f, t
+		}
+	}
+	b.mu.Unlock()
+	b.notify(from, to)
+}
+
+// Release gives back the probe taken by the call, if the breaker is still in
+// the same half-open state.
+func (b *SlidingWindowBreaker) Release(call BreakerCall) {
+	b.mu.Lock()
+	if call.probe && call.gen == b.gen && b.probes > 0 {
+		b.probes--
+	}
+	b.mu.Unlock()
+}
+
+// Fail records a failed call.
+func (b *SlidingWindowBreaker) Fail() {
+	b.record(true, false)
+}
+
+// Success records a successful call.
+func (b *SlidingWindowBreaker) Success() {
+	b.record(false, false)
+}
+
+// FailAfter records a failed call that took d.
+func (b *SlidingWindowBreaker) FailAfter(d time.Duration) {
+	b.record(true, b.slow(d))
+}
+
+// SuccessAfter records a successful call that took d.
+func (b *SlidingWindowBreaker) SuccessAfter(d time.Duration) {
+	b.record(false, b.slow(d))
+}
+
+func (b *SlidingWindowBreaker) slow(d time.Duration) bool {
+	return b.options.SlowCallDuration > 0 && d >= b.options.SlowCallDuration
+}
+
+func (b *SlidingWindowBreaker) record(failed, slow bool) {
+	b.mu.Lock()
+	var (
+		now      = b.options.Clock.Now()
+		from, to = b.refresh(now)
+	)
+	if f, t, changed := b.apply(now, failed, slow); changed {
+		from, to = f, t
+	}
+	b.mu.Unlock()
+	b.notify(from, to)
+}
+
+// apply records the outcome of a call in the current state, changed is true
+// if it caused a state transition.
+func (b *SlidingWindowBreaker) apply(now time.Time, failed, slow bool) (
+	from, to BreakerState, changed bool,
+) {
+	switch b.state {
+	case StateClosed:
+		b.window.record(now, failed, slow)
+		total, failures, slows := b.window.stats(now)
+		if total >= b.options.MinCalls && b.exceeded(total, failures, slows) {
+			from, to = b.transition(StateOpen, now)
+			changed = true
+		}
+	case StateHalfOpen:
+		b.probed++
+		if failed {
+			b.probeErr++
+		}
+		if slow {
+			b.probeSlw++
+		}
+		if b.probed >= b.options.HalfOpenProbes {
+			if b.exceeded(b.probed, b.probeErr, b.probeSlw) {
+				from, to = b.transition(StateOpen, now)
+			} else {
+				from, to = b.transition(StateClosed, now)
+			}
+			changed = true
+		}
+	}
+	return
+}
+
+func (b *SlidingWindowBreaker) exceeded(total, failures, slows int) bool {
+	if total == 0 {
+		return false
+	}
+	if float64(failures)/float64(total) >= b.options.FailureRateThreshold {
+		return true
+	}
+	return b.options.SlowCallDuration > 0 &&
+		b.options.SlowCallRateThreshold > 0 &&
+		float64(slows)/float64(total) >= b.options.SlowCallRateThreshold
+}
+
+// refresh moves the breaker from the open state to the half-open one, once
+// the open duration has expired.
+func (b *SlidingWindowBreaker) refresh(now time.Time) (from, to BreakerState) {
+	if b.state == StateOpen && !now.Before(b.openedAt.Add(b.options.OpenDuration)) {
+		return b.transition(StateHalfOpen, now)
+	}
+	return b.state, b.state
+}
+
+func (b *SlidingWindowBreaker) transition(state BreakerState, now time.Time) (
+	from, to BreakerState,
+) {
+	from, to = b.state, state
+	b.state = state
+	b.gen++
+	b.probes, b.probed, b.probeErr, b.probeSlw = 0, 0, 0, 0
+	b.window.reset()
+	if state == StateOpen {
+		b.openedAt = now
+	}
+	return
+}
+
+func (b *SlidingWindowBreaker) notify(from, to BreakerState) {
+	if from != to && b.options.OnStateChange != nil {
+		b.options.OnStateChange(from, to)
+	}
+}
@@ -0,0 +1,293 @@
+package hooks_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cmd-stream/core-go"
+	hks "github.com/cmd-stream/sender-go/hooks"
+	"github.com/cmd-stream/sender-go/test/helpers"
+	"github.com/cmd-stream/sender-go/test/mocks"
+	cmocks "github.com/cmd-stream/testkit-go/mocks/core"
+	asserterror "github.com/ymz-ncnk/assert/error"
+)
+
+func TestSlidingWindowBreaker(t *testing.T) {
+	t.Run("Should open when the failure rate is reached", func(t *testing.T) {
+		var (
+			clock = helpers.NewClock(time.Unix(0, 0))
+			cb    = hks.NewSlidingWindowBreaker(
+				hks.WithBreakerCountWindow(4),
+				hks.WithBreakerMinCalls(4),
+				hks.WithBreakerFailureRate(0.5),
+				hks.WithBreakerClock(clock),
+			)
+		)
+		cb.Success()
+		cb.Fail()
+		cb.Success()
+		asserterror.Equal(cb.State(), hks.StateClosed, t)
+		cb.Fail()
+		asserterror.Equal(cb.State(), hks.StateOpen, t)
+		asserterror.Equal(cb.Allow(), false, t)
+	})
+
+	t.Run("Count-based window should forget old calls", func(t *testing.T) {
+		cb := hks.NewSlidingWindowBreaker(
+			hks.WithBreakerCountWindow(2),
+			hks.WithBreakerMinCalls(2),
+			hks.WithBreakerFailureRate(1),
+		)
+		cb.Fail()
+		cb.Success()
+		cb.Fail()
+		asserterror.Equal(cb.State(), hks.StateClosed, t)
+		cb.Fail()
+		asserterror.Equal(cb.State(), hks.StateOpen, t)
+	})
+
+	t.Run("Time-based window should forget old calls", func(t *testing.T) {
+		var (
+			clock = helpers.NewClock(time.Unix(0, 0))
+			cb    = hks.NewSlidingWindowBreaker(
+				hks.WithBreakerTimeWindow(10*time.Second, 10),
+				hks.WithBreakerMinCalls(2),
+				hks.WithBreakerFailureRate(1),
+				hks.WithBreakerClock(clock),
+			)
+		)
+		cb.Fail()
+		clock.Advance(10 * time.Second)
+		cb.Fail()
+		asserterror.Equal(cb.State(), hks.StateClosed, t)
+		clock.Advance(9 * time.Second)
+		cb.Fail()
+		asserterror.Equal(cb.State(), hks.StateOpen, t)
+	})
+
+	t.Run("Should open when the slow call rate is reached", func(t *testing.T) {
+		cb := hks.NewSlidingWindowBreaker(
+			hks.WithBreakerCountWindow(2),
+			hks.WithBreakerMinCalls(2),
+			hks.WithBreakerFailureRate(1),
+			hks.WithBreakerSlowCallRate(1, time.Second),
+		)
+		cb.SuccessAfter(time.Second)
+		cb.SuccessAfter(time.Millisecond)
+		asserterror.Equal(cb.State(), hks.StateClosed, t)
+		cb.FailAfter(2 * time.Second)
+		asserterror.Equal(cb.State(), hks.StateClosed, t)
+		cb.SuccessAfter(2 * time.Second)
+		asserterror.Equal(cb.State(), hks.StateOpen, t)
+	})
+
+	t.Run("Should close after successful probes", func(t *testing.T) {
+		var (
+			clock       = helpers.NewClock(time.Unix(0, 0))
+			transitions []hks.BreakerState
+			cb          = hks.NewSlidingWindowBreaker(
+				hks.WithBreakerCountWindow(1),
+				hks.WithBreakerMinCalls(1),
+				hks.WithBreakerOpenDuration(time.Second),
+				hks.WithBreakerHalfOpenProbes(2),
+				hks.WithBreakerClock(clock),
+				hks.WithBreakerOnStateChange(func(from, to hks.BreakerState) {
+					transitions = append(transitions, to)
+				}),
+			)
+		)
+		cb.Fail()
+		clock.Advance(time.Second)
+		asserterror.Equal(cb.Allow(), true, t)
+		asserterror.Equal(cb.Allow(), true, t)
+		asserterror.Equal(cb.Allow(), false, t)
+		cb.Success()
+		asserterror.Equal(cb.State(), hks.StateHalfOpen, t)
+		cb.Success()
+		asserterror.Equal(cb.State(), hks.StateClosed, t)
+		asserterror.EqualDeep(transitions, []hks.BreakerState{hks.StateOpen,
+			hks.StateHalfOpen, hks.StateClosed}, t)
+	})
+
+	t.Run("Should reopen after failed probes", func(t *testing.T) {
+		var (
+			clock = helpers.NewClock(time.Unix(0, 0))
+			cb    = hks.NewSlidingWindowBreaker(
+				hks.WithBreakerCountWindow(1),
+				hks.WithBreakerMinCalls(1),
+				hks.WithBreakerOpenDuration(time.Second),
+				hks.WithBreakerHalfOpenProbes(1),
+				hks.WithBreakerClock(clock),
+			)
+		)
+		cb.Fail()
+		clock.Advance(time.Second)
+		asserterror.Equal(cb.Allow(), true, t)
+		cb.Fail()
+		asserterror.Equal(cb.State(), hks.StateOpen, t)
+		clock.Advance(time.Second - 1)
+		asserterror.Equal(cb.Allow(), false, t)
+	})
+
+	t.Run("Should ignore outcomes of calls allowed before a state change",
+		func(t *testing.T) {
+			var (
+				clock = helpers.NewClock(time.Unix(0, 0))
+				cb    = hks.NewSlidingWindowBreaker(
+					hks.WithBreakerCountWindow(1),
+					hks.WithBreakerMinCalls(1),
+					hks.WithBreakerOpenDuration(time.Second),
+					hks.WithBreakerHalfOpenProbes(1),
+					hks.WithBreakerClock(clock),
+				)
+			)
+			call, ok := cb.AllowCall()
+			asserterror.Equal(ok, true, t)
+			cb.Fail()
+			clock.Advance(time.Second)
+			asserterror.Equal(cb.State(), hks.StateHalfOpen, t)
+			cb.Done(call, false)
+			asserterror.Equal(cb.State(), hks.StateHalfOpen, t)
+			asserterror.Equal(cb.Allow(), true, t)
+		})
+
+	t.Run("Should release a probe", func(t *testing.T) {
+		var (
+			clock = helpers.NewClock(time.Unix(0, 0))
+			cb    = hks.NewSlidingWindowBreaker(
+				hks.WithBreakerCountWindow(1),
+				hks.WithBreakerMinCalls(1),
+				hks.WithBreakerOpenDuration(time.Second),
+				hks.WithBreakerHalfOpenProbes(1),
+				hks.WithBreakerClock(clock),
+			)
+		)
+		cb.Fail()
+		clock.Advance(time.Second)
+		call, ok := cb.AllowCall()
+		asserterror.Equal(ok, true, t)
+		asserterror.Equal(cb.Allow(), false, t)
+		cb.Release(call)
+		asserterror.Equal(cb.Allow(), true, t)
+	})
+
+	t.Run("Should time calls with the clock", func(t *testing.T) {
+		var (
+			clock = helpers.NewClock(time.Unix(0, 0))
+			cb    = hks.NewSlidingWindowBreaker(
+				hks.WithBreakerCountWindow(1),
+				hks.WithBreakerMinCalls(1),
+				hks.WithBreakerFailureRate(1),
+				hks.WithBreakerSlowCallRate(1, time.Second),
+				hks.WithBreakerClock(clock),
+			)
+		)
+		call, _ := cb.AllowCall()
+		clock.Advance(time.Second)
+		cb.Done(call, false)
+		asserterror.Equal(cb.State(), hks.StateOpen, t)
+	})
+
+	t.Run("CircuitBreakerHooks should release the probe if the inner BeforeSend fails",
+		func(t *testing.T) {
+			var (
+				clock = helpers.NewClock(time.Unix(0, 0))
+				cb    = hks.NewSlidingWindowBreaker(
+					hks.WithBreakerCountWindow(1),
+					hks.WithBreakerMinCalls(1),
+					hks.WithBreakerOpenDuration(time.Second),
+					hks.WithBreakerHalfOpenProbes(1),
+					hks.WithBreakerClock(clock),
+				)
+				wantErr = errors.New("BeforeSend error")
+				inner   = mocks.NewHooks[any]().RegisterBeforeSend(
+					func(ctx context.Context, cmd core.Cmd[any]) (context.Context, error) {
+						return ctx, wantErr
+					},
+				)
+				hooks = hks.NewCircuitBreakerHooks[any](cb, inner)
+			)
+			cb.Fail()
+			clock.Advance(time.Second)
+			_, err := hooks.BeforeSend(context.Background(), nil)
+			asserterror.EqualError(err, wantErr, t)
+			asserterror.Equal(cb.Allow(), true, t)
+		})
+
+	t.Run("CircuitBreakerHooks should release the probe of a written Command",
+		func(t *testing.T) {
+			var (
+				clock = helpers.NewClock(time.Unix(0, 0))
+				cb    = hks.NewSlidingWindowBreaker(
+					hks.WithBreakerCountWindow(1),
+					hks.WithBreakerMinCalls(1),
+					hks.WithBreakerOpenDuration(time.Second),
+					hks.WithBreakerHalfOpenProbes(1),
+					hks.WithBreakerClock(clock),
+				)
+				hooks = hks.NewCircuitBreakerHooks[any](cb, hks.NoopHooks[any]{})
+			)
+			cb.Fail()
+			clock.Advance(time.Second)
+			ctx, err := hooks.BeforeSend(context.Background(), nil)
+			asserterror.EqualError(err, nil, t)
+			hks.OnWritten(hooks, ctx, hks.SentCmd[any]{})
+			asserterror.Equal(cb.State(), hks.StateHalfOpen, t)
+			asserterror.Equal(cb.Allow(), true, t)
+		})
+
+	t.Run("CircuitBreakerHooks should count a multi-result probe once",
+		func(t *testing.T) {
+			var (
+				clock = helpers.NewClock(time.Unix(0, 0))
+				cb    = hks.NewSlidingWindowBreaker(
+					hks.WithBreakerCountWindow(1),
+					hks.WithBreakerMinCalls(1),
+					hks.WithBreakerOpenDuration(time.Second),
+					hks.WithBreakerHalfOpenProbes(2),
+					hks.WithBreakerClock(clock),
+				)
+				hooks   = hks.NewCircuitBreakerHooks[any](cb, hks.NoopHooks[any]{})
+				results = []hks.ReceivedResult{
+					{Result: cmocks.NewResult().RegisterLastOne(
+						func() bool { return false })},
+					{Result: cmocks.NewResult().RegisterLastOne(
+						func() bool { return true })},
+				}
+			)
+			cb.Fail()
+			clock.Advance(time.Second)
+			ctx, err := hooks.BeforeSend(context.Background(), nil)
+			asserterror.EqualError(err, nil, t)
+			for _, result := range results {
+				hooks.OnResult(ctx, hks.SentCmd[any]{}, result, nil)
+			}
+			hooks.OnTimeout(ctx, hks.SentCmd[any]{}, errors.New("timeout"))
+			asserterror.Equal(cb.State(), hks.StateHalfOpen, t)
+		})
+
+	t.Run("Should work with CircuitBreakerHooks", func(t *testing.T) {
+		var (
+			cb = hks.NewSlidingWindowBreaker(
+				hks.WithBreakerCountWindow(1),
+				hks.WithBreakerMinCalls(1),
+			)
+			hooks = hks.NewCircuitBreakerHooksFactory(cb,
+				hks.NoopHooksFactory[any]{}).New()
+		)
+		_, err := hooks.BeforeSend(context.Background(), nil)
+		asserterror.EqualError(err, nil, t)
+		hooks.OnError(context.Background(), hks.SentCmd[any]{}, nil)
+		_, err = hooks.BeforeSend(context.Background(), nil)
+		asserterror.EqualError(err, hks.ErrNotAllowed, t)
+	})
+}
+
+func TestBreakerState(t *testing.T) {
+	asserterror.Equal(hks.StateClosed.String(), "closed", t)
+	asserterror.Equal(hks.StateOpen.String(), "open", t)
+	asserterror.Equal(hks.StateHalfOpen.String(), "half-open", t)
+	asserterror.Equal(hks.BreakerState(10).String(), "BreakerState(10)", t)
+}
@@ -0,0 +1,24 @@
+// Package wait provides a context-aware sleep shared by the sender and hooks
+// packages.
+package wait
+
+import (
+	"context"
+	"time"
+)
+
+// For blocks for the specified duration, returns false if the ctx is done
+// earlier.
+func For(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return ctx.Err() == nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -0,0 +1,75 @@
+package sender
+
+import (
+	"container/list"
+	"sync"
+)
+
+// NewLRUStore creates a new LRUStore that holds up to maxEntries entries.
+func NewLRUStore(maxEntries int) *LRUStore {
+	return &LRUStore{
+		maxEntries: maxEntries,
+		list:       list.New(),
+		items:      map[string]*list.Element{},
+	}
+}
+
+// LRUStore is an in-memory CacheStore. When it is full, the least recently
+// used entry is evicted.
+//
+// LRUStore is safe for concurrent use.
+type LRUStore struct {
+	maxEntries int
+
+	mu    sync.Mutex
+	list  *list.List
+	items map[string]*list.Element
+}
+
+type lruItem struct {
+	key   string
+	entry CacheEntry
+}
+
+func (s *LRUStore) Get(key string) (entry CacheEntry, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	elem, ok := s.items[key]
+	if !ok {
+		return
+	}
+	s.list.MoveToFront(elem)
+	return elem.Value.(*lruItem).entry, true
+}
+
+func (s *LRUStore) Set(key string, entry CacheEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if elem, ok := s.items[key]; ok {
+		elem.Value.(*lruItem).entry = entry
+		s.list.MoveToFront(elem)
+		return
+	}
+	s.items[key] = s.list.PushFront(&lruItem{key, entry})
+	for s.list.Len() > max(s.maxEntries, 1) {
+		elem := s.list.Back()
+		s.list.Remove(elem)
+		delete(s.items, elem.Value.(*lruItem).key)
+	}
+}
+
+func (s *LRUStore) Delete(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if elem, ok := s.items[key]; ok {
+		s.list.Remove(elem)
+		delete(s.items, key)
+	}
+}
+
+// Len returns the number of entries.
+func (s *LRUStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.list.Len()
+}
@@ -1,16 +1,54 @@
 package sender
 
 import (
+	"context"
 	"crypto/tls"
+	"net"
+	"sync/atomic"
+	"time"
 
+	cln "github.com/cmd-stream/cmd-stream-go/client"
 	grp "github.com/cmd-stream/cmd-stream-go/group"
 )
 
+// DialErrorFn receives errors of the addresses (used by MakeResolved) whose
+// clients could not be created.
+type DialErrorFn func(addr string, err error)
+
+// DialContextFn establishes a connection to the address on the named network,
+// like net.Dialer.DialContext.
+type DialContextFn func(ctx context.Context, network, addr string) (
+	net.Conn, error)
+
 type MakeOptions[T any] struct {
-	Group        []grp.SetOption[T]
-	Sender       []SetOption[T]
-	TLSConfig    *tls.Config
-	ClientsCount int
+	Group               []grp.SetOption[T]
+	Sender              []SetOption[T]
+	TLSConfig           *tls.Config
+	Network             string
+	DialContext         DialContextFn
+	DialTimeout         time.Duration
+	ClientsCount        int
+	ClientsPerAddr      int
+	DrainTimeout        time.Duration
+	EndpointMaxFailures int
+	EndpointCooldown    time.Duration
+	RedialBackoff       Backoff
+	DialErrorFn         DialErrorFn
+	Sharding            *ShardingOptions
+}
+
+func defaultMakeOptions[T any]() MakeOptions[T] {
+	return MakeOptions[T]{
+		Network:             "tcp",
+		DialContext:         (&net.Dialer{}).DialContext,
+		ClientsCount:        1,
+		ClientsPerAddr:      1,
+		DrainTimeout:        30 * time.Second,
+		EndpointMaxFailures: 3,
+		EndpointCooldown:    5 * time.Second,
+		RedialBackoff: NewJitteredBackoff(
+			NewExponentialBackoff(100*time.Millisecond, 30*time.Second, 2)),
+	}
 }
 
 type SetMakeOption[T any] func(o *MakeOptions[T])
@@ -30,11 +68,101 @@ func WithTLSConfig[T any](conf *tls.Config) SetMakeOption[T] {
 	return func(o *MakeOptions[T]) { o.TLSConfig = conf }
 }
 
+// WithNetwork sets the network name used to connect to the server, such as
+// "tcp6" or "unix" (then the address is the socket path). The default is
+// "tcp".
+func WithNetwork[T any](network string) SetMakeOption[T] {
+	return func(o *MakeOptions[T]) { o.Network = network }
+}
+
+// WithDialer sets the dialer used to connect to the server. It allows to
+// specify the dial timeout, TCP keepalive, local address, etc. The dial
+// timeout also limits the TLS handshake. If the dialer is nil, Make returns
+// ErrNoDialer.
+func WithDialer[T any](dialer *net.Dialer) SetMakeOption[T] {
+	return func(o *MakeOptions[T]) {
+		if dialer == nil {
+			o.DialContext, o.DialTimeout = nil, 0
+			return
+		}
+		o.DialContext = dialer.DialContext
+		o.DialTimeout = dialer.Timeout
+	}
+}
+
+// WithDialContext sets the function used to connect to the server. If the TLS
+// configuration is set, the TLS handshake is performed over the returned
+// connection. If the function is nil, Make returns ErrNoDialer.
+func WithDialContext[T any](fn DialContextFn) SetMakeOption[T] {
+	return func(o *MakeOptions[T]) { o.DialContext = fn }
+}
+
 // WithClientsCount sets the number of clients in the client group.
 func WithClientsCount[T any](count int) SetMakeOption[T] {
 	return func(o *MakeOptions[T]) { o.ClientsCount = count }
 }
 
+// WithClientsPerAddr sets the number of clients created for each address
+// provided by the resolver (used by MakeResolved). The default is 1.
+func WithClientsPerAddr[T any](count int) SetMakeOption[T] {
+	return func(o *MakeOptions[T]) { o.ClientsPerAddr = count }
+}
+
+// WithDrainTimeout sets how long clients of a removed address (used by
+// MakeResolved) wait for in-flight Commands before being closed. The default
+// is 30s.
+func WithDrainTimeout[T any](timeout time.Duration) SetMakeOption[T] {
+	return func(o *MakeOptions[T]) { o.DrainTimeout = timeout }
+}
+
+// WithRedialBackoff sets how long to wait before creating clients of an
+// address (used by MakeResolved) again, if it failed. The default is a
+// jittered exponential backoff from 100ms to 30s, it is also used if the
+// backoff is nil.
+func WithRedialBackoff[T any](backoff Backoff) SetMakeOption[T] {
+	return func(o *MakeOptions[T]) {
+		if backoff != nil {
+			o.RedialBackoff = backoff
+		}
+	}
+}
+
+// WithDialErrorFn sets a function that receives errors of the addresses (used
+// by MakeResolved) whose clients could not be created, both initially and on
+// retries.
+func WithDialErrorFn[T any](fn DialErrorFn) SetMakeOption[T] {
+	return func(o *MakeOptions[T]) { o.DialErrorFn = fn }
+}
+
+// WithEndpointHealth sets when an address (used by MakeMulti and
+// MakeResolved) is considered unhealthy: after maxFailures consecutive send
+// errors. Its clients are then skipped for the cooldown, after which the
+// address is tried again. By default, maxFailures is 3 and cooldown is 5s.
+func WithEndpointHealth[T any](maxFailures int,
+	cooldown time.Duration,
+) SetMakeOption[T] {
+	return func(o *MakeOptions[T]) {
+		o.EndpointMaxFailures = maxFailures
+		o.EndpointCooldown = cooldown
+	}
+}
+
+// WithSharding enables key-based routing: Keyed Commands with the same key
+// are sent through the same client, chosen using consistent hashing with
+// virtual nodes. When a client is lost (closed or its address is unhealthy),
+// only its keys move to the next clients on the ring, and they return once it
+// recovers. Other Commands are dispatched as usual.
+func WithSharding[T any](ops ...SetShardingOption) SetMakeOption[T] {
+	return func(o *MakeOptions[T]) {
+		so := ShardingOptions{
+			VirtualNodes: 100,
+			Hash:         DefaultHash,
+		}
+		ApplySharding(ops, &so)
+		o.Sharding = &so
+	}
+}
+
 func ApplyMakeOptitions[T any](ops []SetMakeOption[T], o *MakeOptions[T]) {
 	for i := range ops {
 		if ops[i] != nil {
@@ -42,3 +170,51 @@ func ApplyMakeOptitions[T any](ops []SetMakeOption[T], o *MakeOptions[T]) {
 		}
 	}
 }
+
+func (o MakeOptions[T]) validate() error {
+	if o.DialContext == nil {
+		return ErrNoDialer
+	}
+	return nil
+}
+
+// connFactory returns the factory of connections to the addr. Its errors are
+// wrapped in DialError.
+func (o MakeOptions[T]) connFactory(addr string) cln.ConnFactoryFn {
+	var attempts atomic.Int64
+	return func() (conn net.Conn, err error) {
+		attempt := int(attempts.Add(1))
+		if conn, err = o.dial(addr); err != nil {
+			err = NewDialError(o.Network, addr, attempt, err)
+		}
+		return
+	}
+}
+
+// dial connects to the addr and performs the TLS handshake, if configured,
+// both within the dial timeout.
+func (o MakeOptions[T]) dial(addr string) (conn net.Conn, err error) {
+	ctx := context.Background()
+	if o.DialTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.DialTimeout)
+		defer cancel()
+	}
+	conn, err = o.DialContext(ctx, o.Network, addr)
+	if err != nil || o.TLSConfig == nil {
+		return
+	}
+	conf := o.TLSConfig
+	if conf.ServerName == "" {
+		if host, _, err := net.SplitHostPort(addr); err == nil {
+			conf = conf.Clone()
+			conf.ServerName = host
+		}
+	}
+	tlsConn := tls.Client(conn, conf)
+	if err = tlsConn.HandshakeContext(ctx); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return tlsConn, nil
+}
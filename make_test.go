@@ -0,0 +1,205 @@
+package sender_test
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cmd-stream/core-go"
+	sndr "github.com/cmd-stream/sender-go"
+	"github.com/cmd-stream/sender-go/test/helpers"
+	asserterror "github.com/ymz-ncnk/assert/error"
+	assertfatal "github.com/ymz-ncnk/assert/fatal"
+)
+
+func TestMakeMulti(t *testing.T) {
+	t.Run("Should dispatch Commands across all addresses", func(t *testing.T) {
+		addr1, server1, err := helpers.StartServer()
+		assertfatal.EqualError(err, nil, t)
+		defer server1.Close()
+		addr2, server2, err := helpers.StartServer()
+		assertfatal.EqualError(err, nil, t)
+		defer server2.Close()
+
+		sender, err := sndr.MakeMulti([]string{addr1, addr2},
+			helpers.ClientCodec{}, sndr.WithClientsCount[struct{}](4))
+		assertfatal.EqualError(err, nil, t)
+		defer sender.Close()
+
+		addrs := map[helpers.AddrResult]int{}
+		for range 4 {
+			result, err := sender.Send(context.Background(), helpers.AddrCmd{})
+			assertfatal.EqualError(err, nil, t)
+			addrs[result.(helpers.AddrResult)]++
+		}
+		asserterror.EqualDeep(addrs, map[helpers.AddrResult]int{
+			helpers.AddrResult(addr1): 2,
+			helpers.AddrResult(addr2): 2,
+		}, t)
+	})
+
+	t.Run("Should skip the address of the closed server", func(t *testing.T) {
+		addr1, server1, err := helpers.StartServer()
+		assertfatal.EqualError(err, nil, t)
+		defer server1.Close()
+		addr2, server2, err := helpers.StartServer()
+		assertfatal.EqualError(err, nil, t)
+
+		sender, err := sndr.MakeMulti([]string{addr1, addr2},
+			helpers.ClientCodec{}, sndr.WithClientsCount[struct{}](2),
+			sndr.WithEndpointHealth[struct{}](1, time.Minute))
+		assertfatal.EqualError(err, nil, t)
+		defer sender.Close()
+
+		server2.Close()
+		time.Sleep(100 * time.Millisecond)
+		for range 4 {
+			result, err := sender.Send(context.Background(), helpers.AddrCmd{})
+			assertfatal.EqualError(err, nil, t)
+			asserterror.Equal(result, core.Result(helpers.AddrResult(addr1)), t)
+		}
+	})
+
+	t.Run("Should skip the address once one of its clients is done",
+		func(t *testing.T) {
+			addr1, server1, err := helpers.StartServer()
+			assertfatal.EqualError(err, nil, t)
+			defer server1.Close()
+			addr2, server2, err := helpers.StartServer()
+			assertfatal.EqualError(err, nil, t)
+			defer server2.Close()
+
+			var conns []net.Conn
+			sender, err := sndr.MakeMulti([]string{addr1, addr2},
+				helpers.ClientCodec{}, sndr.WithClientsCount[struct{}](4),
+				sndr.WithDialContext[struct{}](func(ctx context.Context, network,
+					addr string,
+				) (conn net.Conn, err error) {
+					conn, err = (&net.Dialer{}).DialContext(ctx, network, addr)
+					if err == nil && addr == addr2 {
+						conns = append(conns, conn)
+					}
+					return
+				}),
+			)
+			assertfatal.EqualError(err, nil, t)
+			defer sender.Close()
+
+			conns[0].SetReadDeadline(time.Now()) // fails the client
+			time.Sleep(100 * time.Millisecond)
+			for range 4 {
+				result, err := sender.Send(context.Background(), helpers.AddrCmd{})
+				assertfatal.EqualError(err, nil, t)
+				asserterror.Equal(result, core.Result(helpers.AddrResult(addr1)), t)
+			}
+		})
+
+	t.Run("Should return ErrNoAddrs", func(t *testing.T) {
+		_, err := sndr.MakeMulti(nil, helpers.ClientCodec{})
+		asserterror.EqualError(err, sndr.ErrNoAddrs, t)
+	})
+
+	t.Run("Should return DialError", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		assertfatal.EqualError(err, nil, t)
+		listener.Close()
+		addr := listener.Addr().String()
+		_, err = sndr.Make(addr, helpers.ClientCodec{})
+		var dialErr *sndr.DialError
+		assertfatal.Equal(errors.As(err, &dialErr), true, t)
+		asserterror.Equal(dialErr.Network, "tcp", t)
+		asserterror.Equal(dialErr.Addr, addr, t)
+		asserterror.Equal(dialErr.Attempt, 1, t)
+	})
+}
+
+func TestMake(t *testing.T) {
+	t.Run("Should create one client if ClientsCount is less than 1",
+		func(t *testing.T) {
+			addr, server, err := helpers.StartServer()
+			assertfatal.EqualError(err, nil, t)
+			defer server.Close()
+
+			sender, err := sndr.Make(addr, helpers.ClientCodec{},
+				sndr.WithClientsCount[struct{}](0))
+			assertfatal.EqualError(err, nil, t)
+			defer sender.Close()
+
+			result, err := sender.Broadcast(context.Background(), helpers.AddrCmd{})
+			asserterror.EqualError(err, nil, t)
+			asserterror.Equal(len(result), 1, t)
+			asserterror.Equal(result.Succeeded(), 1, t)
+		})
+}
+
+func TestMakeDialer(t *testing.T) {
+	t.Run("Should connect over a Unix socket", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "server.sock")
+		_, server, err := helpers.StartServerOn("unix", path)
+		assertfatal.EqualError(err, nil, t)
+		defer server.Close()
+
+		sender, err := sndr.Make(path, helpers.ClientCodec{},
+			sndr.WithNetwork[struct{}]("unix"),
+			sndr.WithDialer[struct{}](&net.Dialer{Timeout: time.Second}),
+		)
+		assertfatal.EqualError(err, nil, t)
+		defer sender.Close()
+
+		result, err := sender.Send(context.Background(), helpers.AddrCmd{})
+		asserterror.EqualError(err, nil, t)
+		asserterror.Equal(result, core.Result(helpers.AddrResult(path)), t)
+	})
+
+	t.Run("Should use DialContext", func(t *testing.T) {
+		addr, server, err := helpers.StartServer()
+		assertfatal.EqualError(err, nil, t)
+		defer server.Close()
+
+		var addrs []string
+		sender, err := sndr.Make("server", helpers.ClientCodec{},
+			sndr.WithClientsCount[struct{}](2),
+			sndr.WithDialContext[struct{}](func(ctx context.Context, network,
+				a string,
+			) (net.Conn, error) {
+				addrs = append(addrs, a)
+				return (&net.Dialer{}).DialContext(ctx, network, addr)
+			}),
+		)
+		assertfatal.EqualError(err, nil, t)
+		defer sender.Close()
+		asserterror.EqualDeep(addrs, []string{"server", "server"}, t)
+	})
+
+	t.Run("Should return ErrNoDialer if the dialer is nil", func(t *testing.T) {
+		_, err := sndr.Make("server", helpers.ClientCodec{},
+			sndr.WithDialer[struct{}](nil))
+		asserterror.EqualError(err, sndr.ErrNoDialer, t)
+	})
+
+	t.Run("Dial timeout should limit the TLS handshake", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		assertfatal.EqualError(err, nil, t)
+		defer listener.Close()
+		go func() {
+			conn, err := listener.Accept()
+			if err == nil {
+				defer conn.Close()
+				time.Sleep(time.Second) // never completes the handshake
+			}
+		}()
+
+		start := time.Now()
+		_, err = sndr.Make(listener.Addr().String(), helpers.ClientCodec{},
+			sndr.WithTLSConfig[struct{}](&tls.Config{}),
+			sndr.WithDialer[struct{}](&net.Dialer{Timeout: 50 * time.Millisecond}),
+		)
+		var dialErr *sndr.DialError
+		asserterror.Equal(errors.As(err, &dialErr), true, t)
+		asserterror.Equal(time.Since(start) < 500*time.Millisecond, true, t)
+	})
+}
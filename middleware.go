@@ -0,0 +1,39 @@
+package sender
+
+import (
+	"context"
+	"time"
+
+	"github.com/cmd-stream/core-go"
+)
+
+// SenderAPI is the interface of Sender. It allows to replace the Sender with a
+// fake in tests, or to wrap it with middlewares.
+type SenderAPI[T any] interface {
+	Send(ctx context.Context, cmd core.Cmd[T]) (result core.Result, err error)
+	SendWithDeadline(ctx context.Context, cmd core.Cmd[T],
+		deadline time.Time) (result core.Result, err error)
+	SendMulti(ctx context.Context, cmd core.Cmd[T], resultsCount int,
+		handler ResultHandler) (err error)
+	SendMultiWithDeadline(ctx context.Context, cmd core.Cmd[T],
+		resultsCount int, handler ResultHandler, deadline time.Time) (err error)
+	Close() error
+	Done() <-chan struct{}
+	CloseAndWait(timeout time.Duration) (err error)
+}
+
+var _ SenderAPI[any] = Sender[any]{}
+
+// Middleware adds behavior, such as caching or logging, to a SenderAPI. It
+// usually returns a struct that embeds the next SenderAPI and overrides some
+// of its methods.
+type Middleware[T any] func(next SenderAPI[T]) SenderAPI[T]
+
+// Wrap wraps the SenderAPI with the middlewares. The first middleware is the
+// outermost one, it is the first to receive each call.
+func Wrap[T any](sender SenderAPI[T], mws ...Middleware[T]) SenderAPI[T] {
+	for i := len(mws) - 1; i >= 0; i-- {
+		sender = mws[i](sender)
+	}
+	return sender
+}
@@ -0,0 +1,55 @@
+package sender_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cmd-stream/core-go"
+	sndr "github.com/cmd-stream/sender-go"
+	"github.com/cmd-stream/sender-go/test/mocks"
+	cmocks "github.com/cmd-stream/testkit-go/mocks/core"
+	asserterror "github.com/ymz-ncnk/assert/error"
+	"github.com/ymz-ncnk/mok"
+)
+
+type recordingSender struct {
+	sndr.SenderAPI[any]
+	name  string
+	calls *[]string
+}
+
+func (s recordingSender) Send(ctx context.Context, cmd core.Cmd[any]) (
+	result core.Result, err error,
+) {
+	*s.calls = append(*s.calls, s.name)
+	return s.SenderAPI.Send(ctx, cmd)
+}
+
+func TestWrap(t *testing.T) {
+	var (
+		calls      []string
+		wantResult = cmocks.NewResult()
+		sender     = mocks.NewSender[any]().RegisterSend(
+			func(ctx context.Context, cmd core.Cmd[any]) (core.Result, error) {
+				calls = append(calls, "sender")
+				return wantResult, nil
+			},
+		).RegisterClose(
+			func() error { return nil },
+		)
+		record = func(name string) sndr.Middleware[any] {
+			return func(next sndr.SenderAPI[any]) sndr.SenderAPI[any] {
+				return recordingSender{next, name, &calls}
+			}
+		}
+		wrapped = sndr.Wrap[any](sender, record("first"), record("second"))
+		mocks   = []*mok.Mock{sender.Mock}
+	)
+	result, err := wrapped.Send(context.Background(), cmocks.NewCmd())
+	asserterror.EqualError(err, nil, t)
+	asserterror.EqualDeep(result, core.Result(wantResult), t)
+	asserterror.EqualDeep(calls, []string{"first", "second", "sender"}, t)
+	asserterror.EqualError(wrapped.Close(), nil, t)
+
+	asserterror.EqualDeep(mok.CheckCalls(mocks), mok.EmptyInfomap, t)
+}
@@ -0,0 +1,91 @@
+package sender_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	grp "github.com/cmd-stream/cmd-stream-go/group"
+	"github.com/cmd-stream/core-go"
+	sndr "github.com/cmd-stream/sender-go"
+	"github.com/cmd-stream/sender-go/test/mocks"
+	cmocks "github.com/cmd-stream/testkit-go/mocks/core"
+	asserterror "github.com/ymz-ncnk/assert/error"
+	"github.com/ymz-ncnk/mok"
+)
+
+func TestMultiResultError(t *testing.T) {
+	t.Run("Should report a Result error", func(t *testing.T) {
+		var (
+			resultErr = errors.New("connection lost")
+			group     = mocks.NewClientGroup().RegisterSend(
+				func(cmd core.Cmd[any], results chan<- core.AsyncResult) (
+					seq core.Seq, clientID grp.ClientID, n int, err error,
+				) {
+					results <- core.AsyncResult{Result: cmocks.NewResult().RegisterLastOne(
+						func() bool { return false },
+					)}
+					results <- core.AsyncResult{Error: resultErr}
+					return 1, 0, 10, nil
+				},
+			)
+			sender = sndr.New[any](group)
+		)
+		err := sender.SendMulti(context.Background(), cmocks.NewCmd(), 3,
+			sndr.ResultHandlerFn(func(result core.Result, err error) error {
+				return err
+			}),
+		)
+		asserterror.EqualError(err, &sndr.MultiResultError{
+			Received: 2,
+			Expected: 3,
+			Source:   sndr.SourceServer,
+			LastSeq:  2,
+			Err:      resultErr,
+		}, t)
+		asserterror.Equal(errors.Is(err, resultErr), true, t)
+	})
+
+	t.Run("Should report a ResultHandler error and forget the Command",
+		func(t *testing.T) {
+			var (
+				handlerErr = errors.New("handler error")
+				group      = mocks.NewClientGroup().RegisterSend(
+					func(cmd core.Cmd[any], results chan<- core.AsyncResult) (
+						seq core.Seq, clientID grp.ClientID, n int, err error,
+					) {
+						results <- core.AsyncResult{Result: cmocks.NewResult().RegisterLastOne(
+							func() bool { return false },
+						)}
+						return 1, 2, 10, nil
+					},
+				).RegisterForget(
+					func(seq core.Seq, clientID grp.ClientID) {
+						asserterror.Equal(seq, 1, t)
+						asserterror.Equal(clientID, 2, t)
+					},
+				)
+				sender = sndr.New[any](group)
+				mocks  = []*mok.Mock{group.Mock}
+			)
+			err := sender.SendMulti(context.Background(), cmocks.NewCmd(), 2,
+				sndr.ResultHandlerFn(func(result core.Result, err error) error {
+					return handlerErr
+				}),
+			)
+			var multiErr *sndr.MultiResultError
+			asserterror.Equal(errors.As(err, &multiErr), true, t)
+			asserterror.Equal(multiErr.Source, sndr.SourceHandler, t)
+			asserterror.Equal(multiErr.Received, 1, t)
+			asserterror.EqualError(multiErr.Err, handlerErr, t)
+
+			asserterror.EqualDeep(mok.CheckCalls(mocks), mok.EmptyInfomap, t)
+		})
+}
+
+func TestFailureSource(t *testing.T) {
+	asserterror.Equal(sndr.SourceServer.String(), "server", t)
+	asserterror.Equal(sndr.SourceHandler.String(), "handler", t)
+	asserterror.Equal(sndr.SourceTimeout.String(), "timeout", t)
+	asserterror.Equal(sndr.FailureSource(0).String(), "FailureSource(0)", t)
+}
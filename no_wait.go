@@ -0,0 +1,95 @@
+package sender
+
+import (
+	"context"
+	"time"
+
+	"github.com/cmd-stream/core-go"
+	hks "github.com/cmd-stream/sender-go/hooks"
+)
+
+// ErrorSinkFn receives late errors of the Commands sent with SendNoWait.
+type ErrorSinkFn[T any] func(cmd core.Cmd[T], seq core.Seq, err error)
+
+type NoWaitOptions[T any] struct {
+	Forget      bool
+	Sink        ErrorSinkFn[T]
+	SinkTimeout time.Duration
+}
+
+type SetNoWaitOption[T any] func(o *NoWaitOptions[T])
+
+// WithForget makes the client group to forget the Command right after it is
+// sent, its Results are then handled as unexpected ones by the client.
+func WithForget[T any]() SetNoWaitOption[T] {
+	return func(o *NoWaitOptions[T]) { o.Forget = true }
+}
+
+// WithErrorSink makes SendNoWait to receive the Result in the background and
+// pass its error, if any, to the sink. If no Result arrives within the
+// timeout, the Command is forgotten and ErrTimeout is passed to the sink. If
+// the timeout is not positive, the Result is awaited without a timeout.
+// Ignored if WithForget is set.
+func WithErrorSink[T any](sink ErrorSinkFn[T],
+	timeout time.Duration,
+) SetNoWaitOption[T] {
+	return func(o *NoWaitOptions[T]) {
+		o.Sink = sink
+		o.SinkTimeout = timeout
+	}
+}
+
+func ApplyNoWait[T any](ops []SetNoWaitOption[T], o *NoWaitOptions[T]) {
+	for i := range ops {
+		if ops[i] != nil {
+			ops[i](o)
+		}
+	}
+}
+
+// SendNoWait sends a Command to the server and returns once it is written,
+// without waiting for the Result. Returns the sequence number of the Command
+// and the number of bytes written.
+//
+// If an error sink is set (see WithErrorSink), hooks are called as usual.
+// Otherwise, no Result is received, and hooks.OnWritten is called once the
+// Command is written (for hooks that implement hooks.NoWaitHooks), so hooks
+// that wait for the Command completion, such as BulkheadHooks, release their
+// resources. Unless WithForget is set, the Command must have a single Result.
+func (s Sender[T]) SendNoWait(ctx context.Context, cmd core.Cmd[T],
+	ops ...SetNoWaitOption[T],
+) (seq core.Seq, n int, err error) {
+	if !s.tracker.acquire() {
+		return 0, 0, ErrClosed
+	}
+	o := NoWaitOptions[T]{}
+	ApplyNoWait(ops, &o)
+	results := make(chan core.AsyncResult, 1)
+	f, err := s.dispatch(ctx, cmd, results, time.Time{})
+	if err != nil {
+		s.tracker.release()
+		return f.sentCmd.Seq, f.sentCmd.Size, err
+	}
+	if o.Forget || o.Sink == nil {
+		s.tracker.release()
+		if o.Forget {
+			s.group.Forget(f.sentCmd.Seq, f.clientID)
+		}
+		hks.OnWritten(f.hooks, f.ctx, f.sentCmd)
+		return f.sentCmd.Seq, f.sentCmd.Size, nil
+	}
+	go func() {
+		defer s.tracker.release()
+		cctx := context.WithoutCancel(f.ctx)
+		if o.SinkTimeout > 0 {
+			var cancel context.CancelFunc
+			cctx, cancel = context.WithTimeout(cctx, o.SinkTimeout)
+			defer cancel()
+		}
+		f.ctx = cctx
+		if _, err := s.receive(f, results); err != nil {
+			o.Sink(cmd, f.sentCmd.Seq, err)
+		}
+	}()
+	return f.sentCmd.Seq, f.sentCmd.Size, nil
+}
@@ -0,0 +1,176 @@
+package sender_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	grp "github.com/cmd-stream/cmd-stream-go/group"
+	"github.com/cmd-stream/core-go"
+	sndr "github.com/cmd-stream/sender-go"
+	hks "github.com/cmd-stream/sender-go/hooks"
+	"github.com/cmd-stream/sender-go/test/mocks"
+	cmocks "github.com/cmd-stream/testkit-go/mocks/core"
+	asserterror "github.com/ymz-ncnk/assert/error"
+	"github.com/ymz-ncnk/mok"
+)
+
+func TestSendNoWait(t *testing.T) {
+	t.Run("Should return once the Command is sent", func(t *testing.T) {
+		var (
+			group = mocks.NewClientGroup().RegisterSend(
+				func(cmd core.Cmd[any], results chan<- core.AsyncResult) (
+					seq core.Seq, clientID grp.ClientID, n int, err error,
+				) {
+					return 3, 1, 10, nil
+				},
+			).RegisterForget(
+				func(seq core.Seq, clientID grp.ClientID) {
+					asserterror.Equal(seq, 3, t)
+					asserterror.Equal(clientID, 1, t)
+				},
+			)
+			hooks = mocks.NewHooks[any]().RegisterBeforeSend(
+				func(ctx context.Context, cmd core.Cmd[any]) (context.Context, error) {
+					return ctx, nil
+				},
+			)
+			factory = mocks.NewHooksFactory[any]().RegisterNew(
+				func() hks.Hooks[any] { return hooks },
+			)
+			sender = sndr.New(group, sndr.WithHooksFactory[any](factory))
+			mocks  = []*mok.Mock{group.Mock, hooks.Mock, factory.Mock}
+		)
+		seq, n, err := sender.SendNoWait(context.Background(), cmocks.NewCmd(),
+			sndr.WithForget[any]())
+		asserterror.EqualError(err, nil, t)
+		asserterror.Equal(seq, 3, t)
+		asserterror.Equal(n, 10, t)
+
+		asserterror.EqualDeep(mok.CheckCalls(mocks), mok.EmptyInfomap, t)
+	})
+
+	t.Run("Should call OnError if the send fails", func(t *testing.T) {
+		var (
+			wantErr = errors.New("ClientGroup.Send error")
+			group   = mocks.NewClientGroup().RegisterSend(
+				func(cmd core.Cmd[any], results chan<- core.AsyncResult) (
+					seq core.Seq, clientID grp.ClientID, n int, err error,
+				) {
+					return 0, 0, 0, wantErr
+				},
+			)
+			hooks = mocks.NewHooks[any]().RegisterBeforeSend(
+				func(ctx context.Context, cmd core.Cmd[any]) (context.Context, error) {
+					return ctx, nil
+				},
+			).RegisterOnError(
+				func(ctx context.Context, sentCmd hks.SentCmd[any], err error) {
+					asserterror.EqualError(err, wantErr, t)
+				},
+			)
+			factory = mocks.NewHooksFactory[any]().RegisterNew(
+				func() hks.Hooks[any] { return hooks },
+			)
+			sender = sndr.New(group, sndr.WithHooksFactory[any](factory))
+			mocks  = []*mok.Mock{group.Mock, hooks.Mock, factory.Mock}
+		)
+		_, _, err := sender.SendNoWait(context.Background(), cmocks.NewCmd())
+		asserterror.EqualError(err, wantErr, t)
+
+		asserterror.EqualDeep(mok.CheckCalls(mocks), mok.EmptyInfomap, t)
+	})
+
+	t.Run("Should pass a late error to the sink", func(t *testing.T) {
+		var (
+			wantErr = errors.New("server error")
+			group   = mocks.NewClientGroup().RegisterSend(
+				func(cmd core.Cmd[any], results chan<- core.AsyncResult) (
+					seq core.Seq, clientID grp.ClientID, n int, err error,
+				) {
+					results <- core.AsyncResult{Error: wantErr}
+					return 1, 0, 10, nil
+				},
+			)
+			sender = sndr.New[any](group)
+			errs   = make(chan error, 1)
+			sink   = func(cmd core.Cmd[any], seq core.Seq, err error) {
+				asserterror.Equal(seq, 1, t)
+				errs <- err
+			}
+		)
+		_, _, err := sender.SendNoWait(context.Background(), cmocks.NewCmd(),
+			sndr.WithErrorSink(sink, time.Second))
+		asserterror.EqualError(err, nil, t)
+		asserterror.EqualError(<-errs, wantErr, t)
+	})
+
+	t.Run("Should complete the Command once it is sent", func(t *testing.T) {
+		var (
+			group = mocks.NewClientGroup().RegisterSend(
+				func(cmd core.Cmd[any], results chan<- core.AsyncResult) (
+					seq core.Seq, clientID grp.ClientID, n int, err error,
+				) {
+					return 1, 0, 10, nil
+				},
+			)
+			bulkhead = hks.NewBulkhead(1)
+			factory  = hks.NewBulkheadHooksFactory[any](bulkhead, nil,
+				hks.NoopHooksFactory[any]{})
+			sender = sndr.New(group, sndr.WithHooksFactory[any](factory))
+			mocks  = []*mok.Mock{group.Mock}
+		)
+		_, _, err := sender.SendNoWait(context.Background(), cmocks.NewCmd())
+		asserterror.EqualError(err, nil, t)
+		asserterror.EqualError(bulkhead.Acquire(context.Background()), nil, t)
+
+		asserterror.EqualDeep(mok.CheckCalls(mocks), mok.EmptyInfomap, t)
+	})
+
+	t.Run("Should not time out the sink if the timeout is not positive",
+		func(t *testing.T) {
+			var (
+				wantErr = errors.New("server error")
+				group   = mocks.NewClientGroup().RegisterSend(
+					func(cmd core.Cmd[any], results chan<- core.AsyncResult) (
+						seq core.Seq, clientID grp.ClientID, n int, err error,
+					) {
+						time.AfterFunc(20*time.Millisecond, func() {
+							results <- core.AsyncResult{Error: wantErr}
+						})
+						return 1, 0, 10, nil
+					},
+				)
+				sender = sndr.New[any](group)
+				errs   = make(chan error, 1)
+				sink   = func(cmd core.Cmd[any], seq core.Seq, err error) {
+					errs <- err
+				}
+			)
+			_, _, err := sender.SendNoWait(context.Background(), cmocks.NewCmd(),
+				sndr.WithErrorSink(sink, 0))
+			asserterror.EqualError(err, nil, t)
+			asserterror.EqualError(<-errs, wantErr, t)
+		})
+
+	t.Run("Should pass ErrTimeout to the sink", func(t *testing.T) {
+		var (
+			group = mocks.NewClientGroup().RegisterSend(
+				func(cmd core.Cmd[any], results chan<- core.AsyncResult) (
+					seq core.Seq, clientID grp.ClientID, n int, err error,
+				) {
+					return 1, 0, 10, nil
+				},
+			).RegisterForget(func(seq core.Seq, clientID grp.ClientID) {})
+			sender = sndr.New[any](group)
+			errs   = make(chan error, 1)
+			sink   = func(cmd core.Cmd[any], seq core.Seq, err error) {
+				errs <- err
+			}
+		)
+		sender.SendNoWait(context.Background(), cmocks.NewCmd(),
+			sndr.WithErrorSink(sink, 10*time.Millisecond))
+		asserterror.EqualError(<-errs, sndr.ErrTimeout, t)
+	})
+}
@@ -1,9 +1,20 @@
 package sender
 
-import "github.com/cmd-stream/sender-go/hooks"
+import (
+	"time"
+
+	"github.com/cmd-stream/sender-go/hooks"
+)
 
 type Options[T any] struct {
-	HooksFactory hooks.HooksFactory[T]
+	HooksFactory     hooks.HooksFactory[T]
+	Retry            *RetryOptions
+	Hedge            *HedgeOptions
+	Cache            *CacheOptions
+	Coalesce         bool
+	StreamBufferSize int
+	CtxDeadline      bool
+	DeadlineMargin   time.Duration
 }
 
 type SetOption[T any] func(o *Options[T])
@@ -17,6 +28,96 @@ func WithHooksFactory[T any](factory hooks.HooksFactory[T]) SetOption[T] {
 	}
 }
 
+// WithRetry enables retries for Send and SendWithDeadline.
+//
+// By default, up to 3 attempts are made with a jittered exponential backoff
+// (starting at 100ms, capped at 10s), and only ErrTimeout and transport
+// errors are considered retryable (see RetryTransport). Each attempt creates
+// new hooks, so every failure is visible to them, the attempt number can be
+// retrieved with hooks.AttemptFromContext.
+func WithRetry[T any](ops ...SetRetryOption) SetOption[T] {
+	return func(o *Options[T]) {
+		ro := RetryOptions{
+			MaxAttempts: 3,
+			Backoff: NewJitteredBackoff(
+				NewExponentialBackoff(100*time.Millisecond, 10*time.Second, 2),
+			),
+			Retryable: RetryTransport,
+		}
+		ApplyRetry(ops, &ro)
+		o.Retry = &ro
+	}
+}
+
+// WithHedging enables hedging for Send and SendWithDeadline: if no Result
+// arrives within the delay, the same Command is sent again (through a client
+// not used yet, if the client group is a BroadcastGroup), the first successful
+// Result is returned, and other sends are forgotten.
+//
+// Only Commands approved by the hedgeable function are hedged, by default,
+// those that implement HedgeableCmd. Up to 2 sends are made by default.
+// Each send creates new hooks, its number can be retrieved with
+// hooks.HedgeFromContext. When used with WithRetry, each retry attempt is
+// hedged.
+func WithHedging[T any](delay HedgeDelay, ops ...SetHedgeOption) SetOption[T] {
+	return func(o *Options[T]) {
+		ho := HedgeOptions{
+			Delay:       delay,
+			MaxAttempts: 2,
+			Hedgeable:   IsHedgeableCmd,
+		}
+		ApplyHedge(ops, &ho)
+		o.Hedge = &ho
+	}
+}
+
+// WithCache enables caching of the Results of Send and SendWithDeadline for
+// Commands that implement Cacheable. A fresh cached Result is returned without
+// sending the Command, in which case only hooks.CacheHooks are called. By
+// default, errors are not cached. If the store is nil, caching is disabled.
+func WithCache[T any](store CacheStore, ops ...SetCacheOption) SetOption[T] {
+	return func(o *Options[T]) {
+		if store == nil {
+			o.Cache = nil
+			return
+		}
+		co := CacheOptions{Store: store, Clock: hooks.SystemClock{}}
+		ApplyCache(ops, &co)
+		o.Cache = &co
+	}
+}
+
+// WithCoalescing enables coalescing for Send and SendWithDeadline: a
+// Coalescable Command is not sent if an identical one is already in flight,
+// instead, its Result (or error) is shared by all of them. Waiters may give up
+// independently, the shared Command is forgotten only when all of them have.
+//
+// The shared Command is sent with the ctx values and the deadline of the
+// first waiter, hooks are called for it once.
+func WithCoalescing[T any]() SetOption[T] {
+	return func(o *Options[T]) { o.Coalesce = true }
+}
+
+// WithStreamBufferSize sets the capacity of the Results channel used by
+// SendStream and SendStreamWithDeadline. The default is 16, a negative size
+// is treated as 0.
+func WithStreamBufferSize[T any](size int) SetOption[T] {
+	return func(o *Options[T]) { o.StreamBufferSize = max(0, size) }
+}
+
+// WithContextDeadline makes the sender to use the ctx deadline, if any, as the
+// Command deadline when no explicit deadline is specified. This way the server
+// stops working on Commands the client has already given up on.
+//
+// The margin is subtracted from the ctx deadline to account for the network
+// latency. The ctx returned by hooks.BeforeSend is the one that is checked.
+func WithContextDeadline[T any](margin time.Duration) SetOption[T] {
+	return func(o *Options[T]) {
+		o.CtxDeadline = true
+		o.DeadlineMargin = margin
+	}
+}
+
 func Apply[T any](ops []SetOption[T], o *Options[T]) {
 	for i := range ops {
 		if ops[i] != nil {
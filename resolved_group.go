@@ -0,0 +1,552 @@
+package sender
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	cmdstream "github.com/cmd-stream/cmd-stream-go"
+	cln "github.com/cmd-stream/cmd-stream-go/client"
+	grp "github.com/cmd-stream/cmd-stream-go/group"
+	"github.com/cmd-stream/core-go"
+	"github.com/cmd-stream/sender-go/resolver"
+)
+
+// MakeResolved creates a new Sender that sends Commands to the servers
+// provided by the resolver.
+//
+// It waits (using the ctx) for the first set of addresses. Then, for each new
+// address, clients are created (see WithClientsPerAddr), and clients of the
+// removed addresses stop receiving new Commands, and are closed once their
+// in-flight Commands are completed or the drain timeout expires (see
+// WithDrainTimeout). Clients of unhealthy addresses are skipped (see
+// WithEndpointHealth). Addresses whose clients could not be created are
+// retried with a backoff (see WithRedialBackoff), and their errors are passed
+// to the DialErrorFn, if set (see WithDialErrorFn). Clients are chosen using
+// the dispatch strategy of the group options (round-robin by default).
+//
+// Returns an error if the first set of addresses is not received, or none of
+// its clients can be created.
+func MakeResolved[T any](ctx context.Context, r resolver.Resolver,
+	codec cln.Codec[T],
+	ops ...SetMakeOption[T],
+) (sender Sender[T], err error) {
+	o := defaultMakeOptions[T]()
+	ApplyMakeOptitions(ops, &o)
+	if err = o.validate(); err != nil {
+		return
+	}
+	gro := grp.Options[T]{
+		Factory: grp.RoundRobinStrategyFactory[T]{},
+	}
+	grp.ApplyGroup(o.Group, &gro)
+
+	wctx, cancel := context.WithCancel(context.Background())
+	var (
+		watch = r.Watch(wctx)
+		group = newResolvedGroup(codec, o, gro, cancel)
+		addrs []string
+		ok    bool
+	)
+	select {
+	case <-ctx.Done():
+		cancel()
+		return sender, ctx.Err()
+	case addrs, ok = <-watch:
+	}
+	if !ok || len(addrs) == 0 {
+		cancel()
+		return sender, ErrNoAddrs
+	}
+	if err = group.update(addrs); err != nil && group.empty() {
+		group.Close()
+		err = cmdstream.NewCmdStreamError(err)
+		return
+	}
+	err = nil
+	group.wg.Add(1)
+	go group.watch(watch)
+	return New(group, o.Sender...), nil
+}
+
+func newResolvedGroup[T any](codec cln.Codec[T], o MakeOptions[T],
+	gro grp.Options[T], cancel context.CancelFunc,
+) *resolvedGroup[T] {
+	var ring *hashRing
+	if o.Sharding != nil {
+		ring = newHashRing(*o.Sharding)
+	}
+	return &resolvedGroup[T]{
+		ring:     ring,
+		codec:    codec,
+		options:  o,
+		gro:      gro,
+		cancel:   cancel,
+		done:     make(chan struct{}),
+		clients:  map[grp.ClientID]*resolvedClient[T]{},
+		byAddr:   map[string][]*resolvedClient[T]{},
+		pending:  map[string]pendingAddr{},
+		draining: new(sync.WaitGroup),
+	}
+}
+
+// resolvedGroup is a ClientGroup whose set of clients follows the addresses
+// provided by a Resolver. ClientIDs are never reused.
+type resolvedGroup[T any] struct {
+	codec   cln.Codec[T]
+	options MakeOptions[T]
+	gro     grp.Options[T]
+	cancel  context.CancelFunc
+	done    chan struct{}
+	wg      sync.WaitGroup
+
+	mu       sync.RWMutex
+	nextID   grp.ClientID
+	clients  map[grp.ClientID]*resolvedClient[T]
+	active   []*resolvedClient[T]
+	strategy grp.DispatchStrategy[grp.Client[T]]
+	ring     *hashRing
+	byAddr   map[string][]*resolvedClient[T]
+	pending  map[string]pendingAddr
+	closed   bool
+	draining *sync.WaitGroup
+}
+
+func (g *resolvedGroup[T]) Send(cmd core.Cmd[T],
+	results chan<- core.AsyncResult,
+) (seq core.Seq, clientID grp.ClientID, n int, err error) {
+	c, err := g.next(cmd)
+	if err != nil {
+		return
+	}
+	seq, n, err = c.client.Send(cmd, results)
+	c.sent(seq, err)
+	return seq, c.id, n, err
+}
+
+func (g *resolvedGroup[T]) SendWithDeadline(cmd core.Cmd[T],
+	results chan<- core.AsyncResult,
+	deadline time.Time,
+) (seq core.Seq, clientID grp.ClientID, n int, err error) {
+	c, err := g.next(cmd)
+	if err != nil {
+		return
+	}
+	seq, n, err = c.client.SendWithDeadline(cmd, results, deadline)
+	c.sent(seq, err)
+	return seq, c.id, n, err
+}
+
+func (g *resolvedGroup[T]) ClientIDs() (ids []grp.ClientID) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	ids = make([]grp.ClientID, len(g.active))
+	for i, c := range g.active {
+		ids[i] = c.id
+	}
+	return
+}
+
+func (g *resolvedGroup[T]) SendTo(clientID grp.ClientID, cmd core.Cmd[T],
+	results chan<- core.AsyncResult,
+) (seq core.Seq, n int, err error) {
+	c, err := g.client(clientID)
+	if err != nil {
+		return
+	}
+	seq, n, err = c.client.Send(cmd, results)
+	c.sent(seq, err)
+	return
+}
+
+func (g *resolvedGroup[T]) SendToWithDeadline(clientID grp.ClientID,
+	cmd core.Cmd[T],
+	results chan<- core.AsyncResult,
+	deadline time.Time,
+) (seq core.Seq, n int, err error) {
+	c, err := g.client(clientID)
+	if err != nil {
+		return
+	}
+	seq, n, err = c.client.SendWithDeadline(cmd, results, deadline)
+	c.sent(seq, err)
+	return
+}
+
+func (g *resolvedGroup[T]) available(clientID grp.ClientID) bool {
+	g.mu.RLock()
+	c, pst := g.clients[clientID]
+	g.mu.RUnlock()
+	return pst && c.client.available()
+}
+
+func (g *resolvedGroup[T]) Has(seq core.Seq, clientID grp.ClientID) bool {
+	g.mu.RLock()
+	c, pst := g.clients[clientID]
+	g.mu.RUnlock()
+	return pst && c.client.Has(seq)
+}
+
+func (g *resolvedGroup[T]) Forget(seq core.Seq, clientID grp.ClientID) {
+	g.mu.RLock()
+	c, pst := g.clients[clientID]
+	g.mu.RUnlock()
+	if pst {
+		c.client.Forget(seq)
+		c.untrack(seq)
+	}
+}
+
+func (g *resolvedGroup[T]) Done() <-chan struct{} {
+	return g.done
+}
+
+func (g *resolvedGroup[T]) Err() (err error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	for _, c := range g.active {
+		err = errors.Join(err, c.client.Err())
+	}
+	return
+}
+
+// Close stops watching the resolver and closes all clients, including the
+// draining ones.
+func (g *resolvedGroup[T]) Close() (err error) {
+	g.mu.Lock()
+	if g.closed {
+		g.mu.Unlock()
+		return
+	}
+	g.closed = true
+	clients := make([]*resolvedClient[T], 0, len(g.clients))
+	for _, c := range g.clients {
+		clients = append(clients, c)
+	}
+	g.mu.Unlock()
+	g.cancel()
+	for _, c := range clients {
+		err = errors.Join(err, c.client.Close())
+	}
+	go func() {
+		g.wg.Wait()
+		g.draining.Wait()
+		for _, c := range clients {
+			<-c.client.Done()
+		}
+		close(g.done)
+	}()
+	return
+}
+
+// watch follows changes of the addresses and retries the pending ones.
+func (g *resolvedGroup[T]) watch(addrs <-chan []string) {
+	defer g.wg.Done()
+	for {
+		var retry <-chan time.Time
+		if at, ok := g.nextRedial(); ok {
+			retry = time.After(time.Until(at))
+		}
+		select {
+		case a, ok := <-addrs:
+			if !ok {
+				return
+			}
+			g.update(a)
+		case <-retry:
+			g.redial()
+		}
+	}
+}
+
+// update creates clients for the new addresses, and drains clients of the
+// removed ones. Returns errors of clients that could not be created, their
+// addresses are retried later.
+func (g *resolvedGroup[T]) update(addrs []string) (err error) {
+	var (
+		added   []string
+		removed []*resolvedClient[T]
+		set     = map[string]struct{}{}
+	)
+	g.mu.Lock()
+	for _, addr := range addrs {
+		set[addr] = struct{}{}
+		_, pst := g.byAddr[addr]
+		_, pnd := g.pending[addr]
+		if !pst && !pnd {
+			added = append(added, addr)
+		}
+	}
+	for addr := range g.pending {
+		if _, pst := set[addr]; !pst {
+			delete(g.pending, addr)
+		}
+	}
+	for addr, clients := range g.byAddr {
+		if _, pst := set[addr]; !pst {
+			removed = append(removed, clients...)
+			delete(g.byAddr, addr)
+			for _, c := range clients {
+				if g.ring != nil {
+					g.ring.remove(c.id)
+				}
+			}
+		}
+	}
+	g.setActive()
+	g.mu.Unlock()
+
+	for _, c := range removed {
+		g.draining.Add(1)
+		go g.drain(c)
+	}
+	for _, addr := range added {
+		err = errors.Join(err, g.add(addr, 1))
+	}
+	return
+}
+
+// redial retries the pending addresses whose time has come.
+func (g *resolvedGroup[T]) redial() {
+	var (
+		now = time.Now()
+		due = map[string]int{}
+	)
+	g.mu.Lock()
+	for addr, p := range g.pending {
+		if !now.Before(p.retryAt) {
+			due[addr] = p.attempt
+		}
+	}
+	g.mu.Unlock()
+	for addr, attempt := range due {
+		g.add(addr, attempt+1)
+	}
+}
+
+// nextRedial returns the time of the earliest retry of a pending address.
+func (g *resolvedGroup[T]) nextRedial() (at time.Time, ok bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	for _, p := range g.pending {
+		if !ok || p.retryAt.Before(at) {
+			at, ok = p.retryAt, true
+		}
+	}
+	return
+}
+
+// add creates clients for the address and adds them to the group. If they
+// could not be created, the address becomes pending, and the error is passed
+// to the DialErrorFn.
+func (g *resolvedGroup[T]) add(addr string, attempt int) (err error) {
+	clients, err := g.makeClients(addr)
+	g.mu.Lock()
+	if g.closed {
+		g.mu.Unlock()
+		for _, c := range clients {
+			c.client.Close()
+		}
+		return
+	}
+	if err != nil {
+		g.pending[addr] = pendingAddr{
+			attempt: attempt,
+			retryAt: time.Now().Add(g.options.RedialBackoff.Delay(attempt)),
+		}
+		g.mu.Unlock()
+		if g.options.DialErrorFn != nil {
+			g.options.DialErrorFn(addr, err)
+		}
+		return
+	}
+	delete(g.pending, addr)
+	for i, c := range clients {
+		c.id = g.nextID
+		g.nextID++
+		g.clients[c.id] = c
+		if g.ring != nil {
+			g.ring.add(c.id, clientName(addr, i))
+		}
+	}
+	g.byAddr[addr] = clients
+	g.setActive()
+	g.mu.Unlock()
+	return
+}
+
+func (g *resolvedGroup[T]) makeClients(addr string) (
+	clients []*resolvedClient[T], err error,
+) {
+	var (
+		e = &endpoint{
+			maxFailures: g.options.EndpointMaxFailures,
+			cooldown:    g.options.EndpointCooldown,
+		}
+		factory = g.options.connFactory(addr)
+	)
+	for range max(g.options.ClientsPerAddr, 1) {
+		var client grp.Client[T]
+		if client, err = makeClient(g.codec, factory, g.gro); err != nil {
+			for _, c := range clients {
+				c.client.Close()
+			}
+			return nil, err
+		}
+		clients = append(clients, &resolvedClient[T]{
+			client:  newEndpointClient(client, e),
+			pending: map[core.Seq]struct{}{},
+		})
+	}
+	return
+}
+
+// drain waits until the client has no in-flight Commands (or the drain
+// timeout expires) and closes it.
+func (g *resolvedGroup[T]) drain(c *resolvedClient[T]) {
+	defer g.draining.Done()
+	var (
+		timeout = time.NewTimer(g.options.DrainTimeout)
+		ticker  = time.NewTicker(drainInterval)
+	)
+	defer timeout.Stop()
+	defer ticker.Stop()
+	for c.inFlight() > 0 {
+		select {
+		case <-ticker.C:
+			continue
+		case <-timeout.C:
+		case <-c.client.Done():
+		}
+		break
+	}
+	c.client.Close()
+	g.mu.Lock()
+	delete(g.clients, c.id)
+	g.mu.Unlock()
+}
+
+// next chooses the client of the Keyed Command using the hash ring, if
+// sharding is enabled, and using the dispatch strategy otherwise. Unavailable
+// clients are skipped if possible. The send is counted as in-flight before the
+// lock is released, so the client is not closed by drain meanwhile.
+func (g *resolvedGroup[T]) next(cmd core.Cmd[T]) (c *resolvedClient[T],
+	err error,
+) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	if len(g.active) == 0 {
+		return nil, ErrNoClients
+	}
+	if keyed, ok := cmd.(Keyed); ok && g.ring != nil {
+		id, _ := g.ring.lookup(keyed.Key(), func(id grp.ClientID) bool {
+			return g.clients[id].client.available()
+		})
+		c = g.clients[id]
+	} else {
+		_, index := g.strategy.Next()
+		c = g.active[index]
+	}
+	c.begin()
+	return
+}
+
+// client returns the client with the specified ID, or ErrNoClients if it was
+// removed. Like next, it counts the send as in-flight.
+func (g *resolvedGroup[T]) client(clientID grp.ClientID) (
+	c *resolvedClient[T], err error,
+) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	c, pst := g.clients[clientID]
+	if !pst {
+		return nil, ErrNoClients
+	}
+	c.begin()
+	return
+}
+
+func (g *resolvedGroup[T]) empty() bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return len(g.active) == 0
+}
+
+func (g *resolvedGroup[T]) setActive() {
+	g.active = g.active[:0:0]
+	for _, clients := range g.byAddr {
+		g.active = append(g.active, clients...)
+	}
+	clients := make([]grp.Client[T], len(g.active))
+	for i, c := range g.active {
+		clients[i] = c.client
+	}
+	g.strategy = endpointStrategy[T]{g.gro.Factory.New(clients)}
+}
+
+// pendingAddr is an address whose clients could not be created.
+type pendingAddr struct {
+	attempt int
+	retryAt time.Time
+}
+
+// drainInterval is how often a draining client is checked for in-flight
+// Commands.
+const drainInterval = 100 * time.Millisecond
+
+// resolvedClient keeps track of in-flight Commands of the client.
+type resolvedClient[T any] struct {
+	id     grp.ClientID
+	client endpointClient[T]
+
+	mu      sync.Mutex
+	pending map[core.Seq]struct{}
+	sending int
+	pruneAt int
+}
+
+// begin counts a send that has not yet completed.
+func (c *resolvedClient[T]) begin() {
+	c.mu.Lock()
+	c.sending++
+	c.mu.Unlock()
+}
+
+// sent completes the send counted by begin, and tracks the Command if it was
+// sent successfully.
+func (c *resolvedClient[T]) sent(seq core.Seq, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sending--
+	if err != nil {
+		return
+	}
+	c.pending[seq] = struct{}{}
+	if len(c.pending) >= c.pruneAt {
+		c.prune()
+		c.pruneAt = max(2*len(c.pending), 64)
+	}
+}
+
+func (c *resolvedClient[T]) untrack(seq core.Seq) {
+	c.mu.Lock()
+	delete(c.pending, seq)
+	c.mu.Unlock()
+}
+
+func (c *resolvedClient[T]) inFlight() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.prune()
+	return len(c.pending) + c.sending
+}
+
+// prune removes Commands that are no longer waiting for Results.
+func (c *resolvedClient[T]) prune() {
+	for seq := range c.pending {
+		if !c.client.Has(seq) {
+			delete(c.pending, seq)
+		}
+	}
+}
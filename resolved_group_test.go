@@ -0,0 +1,235 @@
+package sender_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	grp "github.com/cmd-stream/cmd-stream-go/group"
+	"github.com/cmd-stream/core-go"
+	sndr "github.com/cmd-stream/sender-go"
+	"github.com/cmd-stream/sender-go/resolver"
+	"github.com/cmd-stream/sender-go/test/helpers"
+	"github.com/cmd-stream/transport-go"
+	asserterror "github.com/ymz-ncnk/assert/error"
+	assertfatal "github.com/ymz-ncnk/assert/fatal"
+)
+
+func TestMakeResolved(t *testing.T) {
+	t.Run("Should follow changes of the addresses", func(t *testing.T) {
+		addr1, server1, err := helpers.StartServer()
+		assertfatal.EqualError(err, nil, t)
+		defer server1.Close()
+		addr2, server2, err := helpers.StartServer()
+		assertfatal.EqualError(err, nil, t)
+		defer server2.Close()
+
+		r := resolver.NewFake(addr1)
+		sender, err := sndr.MakeResolved(context.Background(), r,
+			helpers.ClientCodec{}, sndr.WithClientsPerAddr[struct{}](2),
+			sndr.WithDrainTimeout[struct{}](time.Second))
+		assertfatal.EqualError(err, nil, t)
+
+		assertfatal.EqualDeep(sendAddrs(sender, 4),
+			map[helpers.AddrResult]int{helpers.AddrResult(addr1): 4}, t)
+
+		r.Set(addr1, addr2)
+		eventually(t, func() bool {
+			return len(sendAddrs(sender, 4)) == 2
+		})
+
+		r.Set(addr2)
+		eventually(t, func() bool {
+			addrs := sendAddrs(sender, 4)
+			return addrs[helpers.AddrResult(addr2)] == 4
+		})
+
+		r.Set()
+		eventually(t, func() bool {
+			_, err := sender.Send(context.Background(), helpers.AddrCmd{})
+			return err == sndr.ErrNoClients
+		})
+
+		asserterror.EqualError(sender.CloseAndWait(time.Second), nil, t)
+	})
+
+	t.Run("Should retry an address whose clients could not be created",
+		func(t *testing.T) {
+			addr1, server1, err := helpers.StartServer()
+			assertfatal.EqualError(err, nil, t)
+			defer server1.Close()
+			addr2, server2, err := helpers.StartServer()
+			assertfatal.EqualError(err, nil, t)
+			defer server2.Close()
+
+			var (
+				fail   atomic.Bool
+				mu     sync.Mutex
+				failed []string
+			)
+			fail.Store(true)
+			sender, err := sndr.MakeResolved(context.Background(),
+				resolver.NewFake(addr1, addr2), helpers.ClientCodec{},
+				sndr.WithDialContext[struct{}](func(ctx context.Context, network,
+					addr string,
+				) (net.Conn, error) {
+					if addr == addr2 && fail.Load() {
+						return nil, errors.New("dial error")
+					}
+					return (&net.Dialer{}).DialContext(ctx, network, addr)
+				}),
+				sndr.WithRedialBackoff[struct{}](
+					sndr.NewConstantBackoff(10*time.Millisecond)),
+				sndr.WithDialErrorFn[struct{}](func(addr string, err error) {
+					mu.Lock()
+					failed = append(failed, addr)
+					mu.Unlock()
+				}),
+			)
+			assertfatal.EqualError(err, nil, t)
+			defer sender.Close()
+
+			assertfatal.EqualDeep(sendAddrs(sender, 4),
+				map[helpers.AddrResult]int{helpers.AddrResult(addr1): 4}, t)
+			mu.Lock()
+			asserterror.Equal(len(failed) > 0 && failed[0] == addr2, true, t)
+			mu.Unlock()
+
+			fail.Store(false)
+			eventually(t, func() bool {
+				return len(sendAddrs(sender, 4)) == 2
+			})
+		})
+
+	t.Run("Should use the dispatch strategy of the group options",
+		func(t *testing.T) {
+			addr1, server1, err := helpers.StartServer()
+			assertfatal.EqualError(err, nil, t)
+			defer server1.Close()
+			addr2, server2, err := helpers.StartServer()
+			assertfatal.EqualError(err, nil, t)
+			defer server2.Close()
+
+			sender, err := sndr.MakeResolved(context.Background(),
+				resolver.NewFake(addr1, addr2), helpers.ClientCodec{},
+				sndr.WithGroup(grp.WithFactory[struct{}](firstStrategyFactory{})),
+			)
+			assertfatal.EqualError(err, nil, t)
+			defer sender.Close()
+
+			asserterror.Equal(len(sendAddrs(sender, 4)), 1, t)
+		})
+
+	t.Run("Should not close a removed client while a send is in progress",
+		func(t *testing.T) {
+			addr1, server1, err := helpers.StartServer()
+			assertfatal.EqualError(err, nil, t)
+			defer server1.Close()
+			addr2, server2, err := helpers.StartServer()
+			assertfatal.EqualError(err, nil, t)
+			defer server2.Close()
+
+			var (
+				r     = resolver.NewFake(addr1)
+				codec = blockingCodec{
+					entered: make(chan struct{}),
+					release: make(chan struct{}),
+					block:   new(atomic.Bool),
+				}
+			)
+			codec.block.Store(true)
+			sender, err := sndr.MakeResolved[struct{}](context.Background(), r,
+				codec, sndr.WithDrainTimeout[struct{}](time.Second))
+			assertfatal.EqualError(err, nil, t)
+			defer sender.Close()
+
+			errs := make(chan error, 1)
+			go func() {
+				result, err := sender.Send(context.Background(), helpers.AddrCmd{})
+				if err == nil && result != helpers.AddrResult(addr1) {
+					err = errors.New("unexpected result")
+				}
+				errs <- err
+			}()
+			<-codec.entered
+			r.Set(addr2)
+			time.Sleep(50 * time.Millisecond)
+			close(codec.release)
+			asserterror.EqualError(<-errs, nil, t)
+		})
+
+	t.Run("Should return ErrNoAddrs", func(t *testing.T) {
+		_, err := sndr.MakeResolved(context.Background(), resolver.NewFake(),
+			helpers.ClientCodec{})
+		asserterror.EqualError(err, sndr.ErrNoAddrs, t)
+	})
+
+	t.Run("Should return an error if no client can be created",
+		func(t *testing.T) {
+			_, err := sndr.MakeResolved(context.Background(),
+				resolver.NewStatic("127.0.0.1:0"), helpers.ClientCodec{})
+			asserterror.Equal(err != nil, true, t)
+		})
+}
+
+func sendAddrs(sender sndr.Sender[struct{}], n int) (
+	addrs map[helpers.AddrResult]int,
+) {
+	addrs = map[helpers.AddrResult]int{}
+	for range n {
+		result, err := sender.Send(context.Background(), helpers.AddrCmd{})
+		if err == nil {
+			addrs[result.(helpers.AddrResult)]++
+		}
+	}
+	return
+}
+
+// blockingCodec blocks the first Encode call until release is closed.
+type blockingCodec struct {
+	helpers.ClientCodec
+	entered chan struct{}
+	release chan struct{}
+	block   *atomic.Bool
+}
+
+func (c blockingCodec) Encode(cmd core.Cmd[struct{}], w transport.Writer) (
+	n int, err error,
+) {
+	if c.block.CompareAndSwap(true, false) {
+		close(c.entered)
+		<-c.release
+	}
+	return c.ClientCodec.Encode(cmd, w)
+}
+
+// firstStrategyFactory creates strategies that always choose the first
+// client.
+type firstStrategyFactory struct{}
+
+func (firstStrategyFactory) New(
+	clients []grp.Client[struct{}],
+) grp.DispatchStrategy[grp.Client[struct{}]] {
+	return firstStrategy(clients)
+}
+
+type firstStrategy []grp.Client[struct{}]
+
+func (s firstStrategy) Next() (grp.Client[struct{}], int64) { return s[0], 0 }
+
+func (s firstStrategy) Slice() []grp.Client[struct{}] { return s }
+
+func eventually(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition is not met")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,108 @@
+package resolver
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"time"
+)
+
+// DNSLookup performs DNS lookups, it is implemented by *net.Resolver.
+type DNSLookup interface {
+	LookupHost(ctx context.Context, host string) (addrs []string, err error)
+	LookupSRV(ctx context.Context, service, proto, name string) (cname string,
+		addrs []*net.SRV, err error)
+}
+
+type DNSOptions struct {
+	Interval time.Duration
+	Lookup   DNSLookup
+}
+
+type SetDNSOption func(o *DNSOptions)
+
+// WithDNSInterval sets how often DNS records are looked up. The default is
+// 30s, a non-positive interval is ignored.
+func WithDNSInterval(interval time.Duration) SetDNSOption {
+	return func(o *DNSOptions) {
+		if interval > 0 {
+			o.Interval = interval
+		}
+	}
+}
+
+// WithDNSLookup sets the DNS lookup implementation. By default,
+// net.DefaultResolver is used.
+func WithDNSLookup(lookup DNSLookup) SetDNSOption {
+	return func(o *DNSOptions) { o.Lookup = lookup }
+}
+
+func ApplyDNS(ops []SetDNSOption, o *DNSOptions) {
+	for i := range ops {
+		if ops[i] != nil {
+			ops[i](o)
+		}
+	}
+}
+
+// NewDNS creates a DNS resolver that looks up A and AAAA records of the host,
+// each address is joined with the port.
+func NewDNS(host, port string, ops ...SetDNSOption) DNS {
+	o := defaultDNSOptions()
+	ApplyDNS(ops, &o)
+	return DNS{
+		interval: o.Interval,
+		lookup: func(ctx context.Context) (addrs []string, err error) {
+			ips, err := o.Lookup.LookupHost(ctx, host)
+			if err != nil {
+				return
+			}
+			addrs = make([]string, len(ips))
+			for i := range ips {
+				addrs[i] = net.JoinHostPort(ips[i], port)
+			}
+			return
+		},
+	}
+}
+
+// NewDNSSRV creates a DNS resolver that looks up SRV records
+// _service._proto.name, each address is the target host joined with the
+// port.
+func NewDNSSRV(service, proto, name string, ops ...SetDNSOption) DNS {
+	o := defaultDNSOptions()
+	ApplyDNS(ops, &o)
+	return DNS{
+		interval: o.Interval,
+		lookup: func(ctx context.Context) (addrs []string, err error) {
+			_, srvs, err := o.Lookup.LookupSRV(ctx, service, proto, name)
+			if err != nil {
+				return
+			}
+			addrs = make([]string, len(srvs))
+			for i := range srvs {
+				addrs[i] = net.JoinHostPort(srvs[i].Target,
+					strconv.Itoa(int(srvs[i].Port)))
+			}
+			return
+		},
+	}
+}
+
+// DNS is a Resolver that periodically looks up DNS records. Lookup errors are
+// ignored, the previous set of addresses is kept.
+type DNS struct {
+	interval time.Duration
+	lookup   LookupFn
+}
+
+func (r DNS) Watch(ctx context.Context) <-chan []string {
+	return poll(ctx, r.interval, r.lookup)
+}
+
+func defaultDNSOptions() DNSOptions {
+	return DNSOptions{
+		Interval: defaultInterval,
+		Lookup:   net.DefaultResolver,
+	}
+}
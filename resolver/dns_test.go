@@ -0,0 +1,92 @@
+package resolver_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/cmd-stream/sender-go/resolver"
+	asserterror "github.com/ymz-ncnk/assert/error"
+)
+
+func TestDNS(t *testing.T) {
+	t.Run("Should send the sorted addresses only when they change",
+		func(t *testing.T) {
+			var (
+				lookup = &dnsLookup{hosts: [][]string{
+					{"10.0.0.2", "10.0.0.1"},
+					{"10.0.0.1", "10.0.0.2"},
+					nil,
+					{"10.0.0.3"},
+				}}
+				r = resolver.NewDNS("example.com", "9000",
+					resolver.WithDNSInterval(time.Millisecond),
+					resolver.WithDNSLookup(lookup))
+				ctx, cancel = context.WithCancel(context.Background())
+			)
+			watch := r.Watch(ctx)
+			asserterror.EqualDeep(<-watch,
+				[]string{"10.0.0.1:9000", "10.0.0.2:9000"}, t)
+			asserterror.EqualDeep(<-watch, []string{"10.0.0.3:9000"}, t)
+			cancel()
+			for range watch {
+			}
+		})
+
+	t.Run("Should look up SRV records", func(t *testing.T) {
+		var (
+			lookup = &dnsLookup{}
+			r      = resolver.NewDNSSRV("cmd", "tcp", "example.com",
+				resolver.WithDNSLookup(lookup))
+			ctx, cancel = context.WithCancel(context.Background())
+		)
+		defer cancel()
+		asserterror.EqualDeep(<-r.Watch(ctx),
+			[]string{"a.example.com:9000", "b.example.com:9001"}, t)
+	})
+
+	t.Run("Should ignore a non-positive interval", func(t *testing.T) {
+		var (
+			lookup = &dnsLookup{hosts: [][]string{{"10.0.0.1"}}}
+			r      = resolver.NewDNS("example.com", "9000",
+				resolver.WithDNSInterval(0), resolver.WithDNSLookup(lookup))
+			ctx, cancel = context.WithCancel(context.Background())
+		)
+		defer cancel()
+		asserterror.EqualDeep(<-r.Watch(ctx), []string{"10.0.0.1:9000"}, t)
+	})
+}
+
+// dnsLookup returns the hosts one by one, nil hosts cause an error.
+type dnsLookup struct {
+	hosts [][]string
+	i     int
+}
+
+func (l *dnsLookup) LookupHost(ctx context.Context, host string) (
+	addrs []string, err error,
+) {
+	if l.i >= len(l.hosts) {
+		return l.hosts[len(l.hosts)-1], nil
+	}
+	addrs = l.hosts[l.i]
+	l.i++
+	if addrs == nil {
+		err = errors.New("lookup error")
+	}
+	return
+}
+
+func (l *dnsLookup) LookupSRV(ctx context.Context, service, proto,
+	name string,
+) (cname string, addrs []*net.SRV, err error) {
+	if service != "cmd" || proto != "tcp" || name != "example.com" {
+		return "", nil, errors.New("unexpected name")
+	}
+	return "", []*net.SRV{
+		{Target: "b.example.com", Port: 9001},
+		{Target: "a.example.com", Port: 9000},
+	}, nil
+}
@@ -0,0 +1,49 @@
+package resolver
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+// NewFake creates a new Fake resolver with the initial set of addresses.
+func NewFake(addrs ...string) *Fake {
+	return &Fake{addrs: addrs, watchers: map[chan []string]context.Context{}}
+}
+
+// Fake is a Resolver for tests, its set of addresses is changed with Set.
+type Fake struct {
+	mu       sync.Mutex
+	addrs    []string
+	watchers map[chan []string]context.Context
+}
+
+// Set changes the set of addresses and passes it to all watchers. It blocks
+// while a watcher has not received the previous set.
+func (r *Fake) Set(addrs ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.addrs = slices.Clone(addrs)
+	for c, ctx := range r.watchers {
+		select {
+		case c <- slices.Clone(addrs):
+		case <-ctx.Done():
+		}
+	}
+}
+
+func (r *Fake) Watch(ctx context.Context) <-chan []string {
+	c := make(chan []string, 1)
+	r.mu.Lock()
+	c <- slices.Clone(r.addrs)
+	r.watchers[c] = ctx
+	r.mu.Unlock()
+	go func() {
+		<-ctx.Done()
+		r.mu.Lock()
+		delete(r.watchers, c)
+		close(c)
+		r.mu.Unlock()
+	}()
+	return c
+}
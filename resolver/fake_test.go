@@ -0,0 +1,39 @@
+package resolver_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cmd-stream/sender-go/resolver"
+	asserterror "github.com/ymz-ncnk/assert/error"
+)
+
+func TestFake(t *testing.T) {
+	var (
+		r           = resolver.NewFake("127.0.0.1:9000")
+		ctx, cancel = context.WithCancel(context.Background())
+	)
+	watch := r.Watch(ctx)
+	asserterror.EqualDeep(<-watch, []string{"127.0.0.1:9000"}, t)
+
+	r.Set("127.0.0.1:9001", "127.0.0.1:9002")
+	asserterror.EqualDeep(<-watch, []string{"127.0.0.1:9001", "127.0.0.1:9002"},
+		t)
+
+	cancel()
+	_, ok := <-watch
+	asserterror.Equal(ok, false, t)
+	r.Set("127.0.0.1:9003")
+}
+
+func TestStatic(t *testing.T) {
+	var (
+		r           = resolver.NewStatic("127.0.0.1:9000")
+		ctx, cancel = context.WithCancel(context.Background())
+	)
+	watch := r.Watch(ctx)
+	asserterror.EqualDeep(<-watch, []string{"127.0.0.1:9000"}, t)
+	cancel()
+	_, ok := <-watch
+	asserterror.Equal(ok, false, t)
+}
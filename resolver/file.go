@@ -0,0 +1,49 @@
+package resolver
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"strings"
+	"time"
+)
+
+// NewFile creates a new File resolver, the file is checked for changes with
+// the specified interval. If the interval is not positive, 30s is used.
+func NewFile(path string, interval time.Duration) File {
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+	return File{path, interval}
+}
+
+// File is a Resolver that reads addresses from a file, one per line. Empty
+// lines and lines starting with # are skipped. If the file cannot be read,
+// the previous set of addresses is kept.
+type File struct {
+	path     string
+	interval time.Duration
+}
+
+func (r File) Watch(ctx context.Context) <-chan []string {
+	return poll(ctx, r.interval, r.read)
+}
+
+func (r File) read(ctx context.Context) (addrs []string, err error) {
+	f, err := os.Open(r.path)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	addrs = []string{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		addrs = append(addrs, line)
+	}
+	err = scanner.Err()
+	return
+}
@@ -0,0 +1,48 @@
+package resolver_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cmd-stream/sender-go/resolver"
+	asserterror "github.com/ymz-ncnk/assert/error"
+	assertfatal "github.com/ymz-ncnk/assert/fatal"
+)
+
+func TestFile(t *testing.T) {
+	t.Run("Should send the addresses when the file changes", func(t *testing.T) {
+		var (
+			path        = filepath.Join(t.TempDir(), "addrs")
+			r           = resolver.NewFile(path, time.Millisecond)
+			ctx, cancel = context.WithCancel(context.Background())
+		)
+		defer cancel()
+		err := os.WriteFile(path, []byte("# servers\n\n127.0.0.1:9001\n"+
+			"  127.0.0.1:9000  \n"), 0o600)
+		assertfatal.EqualError(err, nil, t)
+
+		watch := r.Watch(ctx)
+		asserterror.EqualDeep(<-watch, []string{"127.0.0.1:9000", "127.0.0.1:9001"},
+			t)
+
+		err = os.WriteFile(path, []byte("127.0.0.1:9002\n"), 0o600)
+		assertfatal.EqualError(err, nil, t)
+		asserterror.EqualDeep(<-watch, []string{"127.0.0.1:9002"}, t)
+	})
+
+	t.Run("Should use the default interval if it is not positive",
+		func(t *testing.T) {
+			var (
+				path        = filepath.Join(t.TempDir(), "addrs")
+				r           = resolver.NewFile(path, 0)
+				ctx, cancel = context.WithCancel(context.Background())
+			)
+			defer cancel()
+			err := os.WriteFile(path, []byte("127.0.0.1:9000\n"), 0o600)
+			assertfatal.EqualError(err, nil, t)
+			asserterror.EqualDeep(<-r.Watch(ctx), []string{"127.0.0.1:9000"}, t)
+		})
+}
@@ -0,0 +1,59 @@
+// Package resolver provides Resolvers, which supply a Sender with a dynamic
+// set of server addresses.
+package resolver
+
+import (
+	"context"
+	"slices"
+	"time"
+)
+
+// Resolver provides a set of server addresses, which may change over time.
+type Resolver interface {
+	// Watch returns a channel that receives the current set of addresses, and
+	// then each changed one. The channel is closed when the ctx is done.
+	Watch(ctx context.Context) <-chan []string
+}
+
+// defaultInterval is used for a non-positive poll interval.
+const defaultInterval = 30 * time.Second
+
+// LookupFn returns the current set of addresses.
+type LookupFn func(ctx context.Context) (addrs []string, err error)
+
+// poll calls the lookup function with the specified interval and sends its
+// sorted result to the returned channel if it differs from the previous one.
+// Lookup errors are ignored, the previous set is kept.
+func poll(ctx context.Context, interval time.Duration, lookup LookupFn) (
+	ch <-chan []string,
+) {
+	c := make(chan []string, 1)
+	go func() {
+		defer close(c)
+		var (
+			prev   []string
+			sent   bool
+			ticker = time.NewTicker(interval)
+		)
+		defer ticker.Stop()
+		for {
+			if addrs, err := lookup(ctx); err == nil {
+				slices.Sort(addrs)
+				if !sent || !slices.Equal(addrs, prev) {
+					select {
+					case c <- addrs:
+					case <-ctx.Done():
+						return
+					}
+					prev, sent = addrs, true
+				}
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return c
+}
@@ -0,0 +1,195 @@
+package sender
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"time"
+
+	"github.com/cmd-stream/core-go"
+	ccln "github.com/cmd-stream/core-go/client"
+	hks "github.com/cmd-stream/sender-go/hooks"
+	"github.com/cmd-stream/sender-go/internal/wait"
+)
+
+// Backoff determines how long to wait before the next attempt.
+type Backoff interface {
+	// Delay returns the delay after the specified failed attempt, attempts are
+	// numbered starting from 1.
+	Delay(attempt int) time.Duration
+}
+
+// BackoffFn is a function type that implements the Backoff interface.
+type BackoffFn func(attempt int) time.Duration
+
+func (fn BackoffFn) Delay(attempt int) time.Duration {
+	return fn(attempt)
+}
+
+// NewConstantBackoff creates a new ConstantBackoff.
+func NewConstantBackoff(interval time.Duration) ConstantBackoff {
+	return ConstantBackoff{interval}
+}
+
+// ConstantBackoff waits the same interval before each attempt.
+type ConstantBackoff struct {
+	interval time.Duration
+}
+
+func (b ConstantBackoff) Delay(attempt int) time.Duration {
+	return b.interval
+}
+
+// NewExponentialBackoff creates a new ExponentialBackoff.
+func NewExponentialBackoff(initial, max time.Duration,
+	multiplier float64,
+) ExponentialBackoff {
+	return ExponentialBackoff{initial, max, multiplier}
+}
+
+// ExponentialBackoff multiplies the delay by the multiplier after each attempt,
+// starting with the initial value. The delay never exceeds max, if max > 0.
+type ExponentialBackoff struct {
+	initial    time.Duration
+	max        time.Duration
+	multiplier float64
+}
+
+func (b ExponentialBackoff) Delay(attempt int) time.Duration {
+	delay := float64(b.initial)
+	for i := 1; i < attempt; i++ {
+		if b.max > 0 && delay >= float64(b.max) {
+			break
+		}
+		delay *= b.multiplier
+	}
+	if b.max > 0 && delay >= float64(b.max) {
+		return b.max
+	}
+	return time.Duration(delay)
+}
+
+// NewJitteredBackoff creates a new JitteredBackoff.
+func NewJitteredBackoff(backoff Backoff) JitteredBackoff {
+	return JitteredBackoff{backoff}
+}
+
+// JitteredBackoff randomizes the delay of the wrapped Backoff, returning a
+// value in the range [0, delay) ("full jitter"). This helps to avoid retry
+// storms when many senders fail at the same time.
+type JitteredBackoff struct {
+	backoff Backoff
+}
+
+func (b JitteredBackoff) Delay(attempt int) time.Duration {
+	delay := b.backoff.Delay(attempt)
+	if delay <= 0 {
+		return 0
+	}
+	return rand.N(delay)
+}
+
+// RetryableFn decides whether the failed attempt should be repeated.
+type RetryableFn func(err error) bool
+
+// RetryTransport is the default RetryableFn, it treats ErrTimeout and
+// transport errors (network errors, io.EOF, a closed client) as retryable.
+// Errors returned by hooks, such as hooks.ErrNotAllowed, are not retried.
+func RetryTransport(err error) bool {
+	if errors.Is(err, ErrTimeout) || errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, ccln.ErrClosed) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// RetryAll is a RetryableFn that treats all errors as retryable.
+func RetryAll(err error) bool {
+	return true
+}
+
+type RetryOptions struct {
+	MaxAttempts    int
+	MaxElapsed     time.Duration
+	AttemptTimeout time.Duration
+	Backoff        Backoff
+	Retryable      RetryableFn
+}
+
+type SetRetryOption func(o *RetryOptions)
+
+// WithRetryMaxAttempts sets the maximum number of attempts, including the
+// first one. If n <= 0, the number of attempts is unlimited.
+func WithRetryMaxAttempts(n int) SetRetryOption {
+	return func(o *RetryOptions) { o.MaxAttempts = n }
+}
+
+// WithRetryMaxElapsed sets the time budget for all attempts. No new attempt
+// is made if it would start after the budget is exhausted.
+func WithRetryMaxElapsed(d time.Duration) SetRetryOption {
+	return func(o *RetryOptions) { o.MaxElapsed = d }
+}
+
+// WithRetryAttemptTimeout sets the timeout for a single attempt. Once it
+// expires, the Command is forgotten and the attempt fails with ErrTimeout.
+func WithRetryAttemptTimeout(d time.Duration) SetRetryOption {
+	return func(o *RetryOptions) { o.AttemptTimeout = d }
+}
+
+// WithRetryBackoff sets the backoff policy used between attempts.
+func WithRetryBackoff(backoff Backoff) SetRetryOption {
+	return func(o *RetryOptions) { o.Backoff = backoff }
+}
+
+// WithRetryable sets a function that classifies errors as retryable.
+func WithRetryable(fn RetryableFn) SetRetryOption {
+	return func(o *RetryOptions) { o.Retryable = fn }
+}
+
+func ApplyRetry(ops []SetRetryOption, o *RetryOptions) {
+	for i := range ops {
+		if ops[i] != nil {
+			ops[i](o)
+		}
+	}
+}
+
+func (s Sender[T]) sendRetry(ctx context.Context, cmd core.Cmd[T],
+	deadline time.Time,
+) (result core.Result, err error) {
+	var (
+		o     = s.options.Retry
+		start = time.Now()
+	)
+	for attempt := 1; ; attempt++ {
+		result, err = s.sendAttempt(ctx, cmd, deadline, attempt)
+		if err == nil || !o.Retryable(err) || ctx.Err() != nil {
+			return
+		}
+		if o.MaxAttempts > 0 && attempt >= o.MaxAttempts {
+			return
+		}
+		delay := o.Backoff.Delay(attempt)
+		if o.MaxElapsed > 0 && time.Since(start)+delay >= o.MaxElapsed {
+			return
+		}
+		if !wait.For(ctx, delay) {
+			return
+		}
+	}
+}
+
+func (s Sender[T]) sendAttempt(ctx context.Context, cmd core.Cmd[T],
+	deadline time.Time, attempt int,
+) (result core.Result, err error) {
+	ctx = hks.ContextWithAttempt(ctx, attempt)
+	if timeout := s.options.Retry.AttemptTimeout; timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	return s.sendSingle(ctx, cmd, deadline)
+}
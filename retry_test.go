@@ -0,0 +1,299 @@
+package sender_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	grp "github.com/cmd-stream/cmd-stream-go/group"
+	"github.com/cmd-stream/core-go"
+	ccln "github.com/cmd-stream/core-go/client"
+	sndr "github.com/cmd-stream/sender-go"
+	hks "github.com/cmd-stream/sender-go/hooks"
+	"github.com/cmd-stream/sender-go/test/mocks"
+	cmocks "github.com/cmd-stream/testkit-go/mocks/core"
+	asserterror "github.com/ymz-ncnk/assert/error"
+	"github.com/ymz-ncnk/mok"
+)
+
+func TestRetry(t *testing.T) {
+	t.Run("Should retry until success", func(t *testing.T) {
+		var (
+			wantCmd    = cmocks.NewCmd()
+			wantResult = cmocks.NewResult()
+			sendErr    = fmt.Errorf("ClientGroup.Send error: %w", io.EOF)
+			group      = mocks.NewClientGroup().RegisterSend(
+				func(cmd core.Cmd[any], results chan<- core.AsyncResult) (
+					seq core.Seq, clientID grp.ClientID, n int, err error,
+				) {
+					return 1, 0, 0, sendErr
+				},
+			).RegisterSend(
+				func(cmd core.Cmd[any], results chan<- core.AsyncResult) (
+					seq core.Seq, clientID grp.ClientID, n int, err error,
+				) {
+					results <- core.AsyncResult{Seq: 2, BytesRead: 5, Result: wantResult}
+					return 2, 1, 10, nil
+				},
+			)
+			hooks1 = mocks.NewHooks[any]().RegisterBeforeSend(
+				func(ctx context.Context, cmd core.Cmd[any]) (context.Context, error) {
+					attempt, ok := hks.AttemptFromContext(ctx)
+					asserterror.Equal(ok, true, t)
+					asserterror.Equal(attempt, 1, t)
+					return ctx, nil
+				},
+			).RegisterOnError(
+				func(ctx context.Context, sentCmd hks.SentCmd[any], err error) {
+					asserterror.EqualError(err, sendErr, t)
+				},
+			)
+			hooks2 = mocks.NewHooks[any]().RegisterBeforeSend(
+				func(ctx context.Context, cmd core.Cmd[any]) (context.Context, error) {
+					attempt, _ := hks.AttemptFromContext(ctx)
+					asserterror.Equal(attempt, 2, t)
+					return ctx, nil
+				},
+			).RegisterOnResult(
+				func(ctx context.Context, sentCmd hks.SentCmd[any],
+					recvResult hks.ReceivedResult, err error,
+				) {
+					asserterror.Equal(sentCmd.Seq, core.Seq(2), t)
+				},
+			)
+			factory = mocks.NewHooksFactory[any]().RegisterNew(
+				func() hks.Hooks[any] { return hooks1 },
+			).RegisterNew(
+				func() hks.Hooks[any] { return hooks2 },
+			)
+			sender = sndr.New(group, sndr.WithHooksFactory[any](factory),
+				sndr.WithRetry[any](
+					sndr.WithRetryBackoff(sndr.NewConstantBackoff(0)),
+				),
+			)
+			mocks = []*mok.Mock{group.Mock, hooks1.Mock, hooks2.Mock, factory.Mock}
+		)
+		result, err := sender.Send(context.Background(), wantCmd)
+		asserterror.EqualError(err, nil, t)
+		asserterror.EqualDeep(result, core.Result(wantResult), t)
+
+		asserterror.EqualDeep(mok.CheckCalls(mocks), mok.EmptyInfomap, t)
+	})
+
+	t.Run("Should stop after MaxAttempts", func(t *testing.T) {
+		var (
+			wantErr = fmt.Errorf("ClientGroup.SendWithDeadline error: %w", io.EOF)
+			group   = mocks.NewClientGroup()
+			hooks   = mocks.NewHooks[any]()
+			factory = mocks.NewHooksFactory[any]()
+			sender  = sndr.New(group, sndr.WithHooksFactory[any](factory),
+				sndr.WithRetry[any](
+					sndr.WithRetryMaxAttempts(2),
+					sndr.WithRetryBackoff(sndr.NewConstantBackoff(0)),
+				),
+			)
+			mocks = []*mok.Mock{group.Mock, hooks.Mock, factory.Mock}
+		)
+		for range 2 {
+			group.RegisterSendWithDeadline(
+				func(cmd core.Cmd[any], results chan<- core.AsyncResult,
+					deadline time.Time,
+				) (seq core.Seq, clientID grp.ClientID, n int, err error) {
+					return 1, 0, 0, wantErr
+				},
+			)
+			hooks.RegisterBeforeSend(
+				func(ctx context.Context, cmd core.Cmd[any]) (context.Context, error) {
+					return ctx, nil
+				},
+			).RegisterOnError(
+				func(ctx context.Context, sentCmd hks.SentCmd[any], err error) {},
+			)
+			factory.RegisterNew(func() hks.Hooks[any] { return *hooks })
+		}
+		_, err := sender.SendWithDeadline(context.Background(), cmocks.NewCmd(),
+			time.Now().Add(time.Second))
+		asserterror.EqualError(err, wantErr, t)
+
+		asserterror.EqualDeep(mok.CheckCalls(mocks), mok.EmptyInfomap, t)
+	})
+
+	t.Run("Should not retry a non-retryable error", func(t *testing.T) {
+		var (
+			wantErr = errors.New("BeforeSend error")
+			hooks   = mocks.NewHooks[any]().RegisterBeforeSend(
+				func(ctx context.Context, cmd core.Cmd[any]) (context.Context, error) {
+					return ctx, wantErr
+				},
+			)
+			factory = mocks.NewHooksFactory[any]().RegisterNew(
+				func() hks.Hooks[any] { return hooks },
+			)
+			sender = sndr.New(mocks.NewClientGroup(),
+				sndr.WithHooksFactory[any](factory),
+				sndr.WithRetry[any](
+					sndr.WithRetryable(func(err error) bool { return err != wantErr }),
+				),
+			)
+			mocks = []*mok.Mock{hooks.Mock, factory.Mock}
+		)
+		_, err := sender.Send(context.Background(), cmocks.NewCmd())
+		asserterror.EqualError(err, wantErr, t)
+
+		asserterror.EqualDeep(mok.CheckCalls(mocks), mok.EmptyInfomap, t)
+	})
+
+	t.Run("Should not retry hook errors by default", func(t *testing.T) {
+		var (
+			hooks = mocks.NewHooks[any]().RegisterBeforeSend(
+				func(ctx context.Context, cmd core.Cmd[any]) (context.Context, error) {
+					return ctx, hks.ErrNotAllowed
+				},
+			)
+			factory = mocks.NewHooksFactory[any]().RegisterNew(
+				func() hks.Hooks[any] { return hooks },
+			)
+			sender = sndr.New(mocks.NewClientGroup(),
+				sndr.WithHooksFactory[any](factory),
+				sndr.WithRetry[any](),
+			)
+			mocks = []*mok.Mock{hooks.Mock, factory.Mock}
+		)
+		_, err := sender.Send(context.Background(), cmocks.NewCmd())
+		asserterror.EqualError(err, hks.ErrNotAllowed, t)
+
+		asserterror.EqualDeep(mok.CheckCalls(mocks), mok.EmptyInfomap, t)
+	})
+
+	t.Run("Should forget a timed out attempt", func(t *testing.T) {
+		var (
+			wantSeq      core.Seq     = 1
+			wantClientID grp.ClientID = 3
+			wantResult                = cmocks.NewResult()
+			group                     = mocks.NewClientGroup().RegisterSend(
+				func(cmd core.Cmd[any], results chan<- core.AsyncResult) (
+					seq core.Seq, clientID grp.ClientID, n int, err error,
+				) {
+					return wantSeq, wantClientID, 10, nil
+				},
+			).RegisterForget(
+				func(seq core.Seq, clientID grp.ClientID) {
+					asserterror.Equal(seq, wantSeq, t)
+					asserterror.Equal(clientID, wantClientID, t)
+				},
+			).RegisterSend(
+				func(cmd core.Cmd[any], results chan<- core.AsyncResult) (
+					seq core.Seq, clientID grp.ClientID, n int, err error,
+				) {
+					results <- core.AsyncResult{Result: wantResult}
+					return 2, 0, 10, nil
+				},
+			)
+			hooks = mocks.NewHooks[any]().RegisterNBeforeSend(2,
+				func(ctx context.Context, cmd core.Cmd[any]) (context.Context, error) {
+					return ctx, nil
+				},
+			).RegisterOnTimeout(
+				func(ctx context.Context, sentCmd hks.SentCmd[any], err error) {
+					asserterror.EqualError(err, sndr.ErrTimeout, t)
+				},
+			).RegisterOnResult(
+				func(ctx context.Context, sentCmd hks.SentCmd[any],
+					recvResult hks.ReceivedResult, err error,
+				) {
+				},
+			)
+			factory = mocks.NewHooksFactory[any]().RegisterNNew(2,
+				func() hks.Hooks[any] { return hooks },
+			)
+			sender = sndr.New(group, sndr.WithHooksFactory[any](factory),
+				sndr.WithRetry[any](
+					sndr.WithRetryAttemptTimeout(10*time.Millisecond),
+					sndr.WithRetryBackoff(sndr.NewConstantBackoff(0)),
+				),
+			)
+			mocks = []*mok.Mock{group.Mock, hooks.Mock, factory.Mock}
+		)
+		result, err := sender.Send(context.Background(), cmocks.NewCmd())
+		asserterror.EqualError(err, nil, t)
+		asserterror.EqualDeep(result, core.Result(wantResult), t)
+
+		asserterror.EqualDeep(mok.CheckCalls(mocks), mok.EmptyInfomap, t)
+	})
+
+	t.Run("Should stop if the ctx is done", func(t *testing.T) {
+		var (
+			ctx, cancel = context.WithCancel(context.Background())
+			wantErr     = fmt.Errorf("ClientGroup.Send error: %w", io.EOF)
+			group       = mocks.NewClientGroup().RegisterSend(
+				func(cmd core.Cmd[any], results chan<- core.AsyncResult) (
+					seq core.Seq, clientID grp.ClientID, n int, err error,
+				) {
+					cancel()
+					return 1, 0, 0, wantErr
+				},
+			)
+			sender = sndr.New(group, sndr.WithRetry[any]())
+			mocks  = []*mok.Mock{group.Mock}
+		)
+		_, err := sender.Send(ctx, cmocks.NewCmd())
+		asserterror.EqualError(err, wantErr, t)
+
+		asserterror.EqualDeep(mok.CheckCalls(mocks), mok.EmptyInfomap, t)
+	})
+}
+
+func TestRetryTransport(t *testing.T) {
+	var (
+		opErr error = &net.OpError{Op: "read", Err: errors.New("reset")}
+		cases       = []struct {
+			err  error
+			want bool
+		}{
+			{sndr.ErrTimeout, true},
+			{io.EOF, true},
+			{fmt.Errorf("cmdstream client: %w", ccln.ErrClosed), true},
+			{opErr, true},
+			{hks.ErrNotAllowed, false},
+			{hks.ErrRateLimited, false},
+			{errors.New("result error"), false},
+		}
+	)
+	for _, c := range cases {
+		asserterror.Equal(sndr.RetryTransport(c.err), c.want, t)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	t.Run("ConstantBackoff", func(t *testing.T) {
+		b := sndr.NewConstantBackoff(time.Second)
+		asserterror.Equal(b.Delay(1), time.Second, t)
+		asserterror.Equal(b.Delay(5), time.Second, t)
+	})
+
+	t.Run("ExponentialBackoff", func(t *testing.T) {
+		b := sndr.NewExponentialBackoff(time.Second, 5*time.Second, 2)
+		asserterror.Equal(b.Delay(1), time.Second, t)
+		asserterror.Equal(b.Delay(2), 2*time.Second, t)
+		asserterror.Equal(b.Delay(3), 4*time.Second, t)
+		asserterror.Equal(b.Delay(4), 5*time.Second, t)
+
+		b = sndr.NewExponentialBackoff(10*time.Second, 5*time.Second, 2)
+		asserterror.Equal(b.Delay(1), 5*time.Second, t)
+	})
+
+	t.Run("JitteredBackoff", func(t *testing.T) {
+		b := sndr.NewJitteredBackoff(sndr.NewConstantBackoff(time.Second))
+		for range 100 {
+			if d := b.Delay(1); d < 0 || d >= time.Second {
+				t.Fatalf("unexpected delay %v", d)
+			}
+		}
+		b = sndr.NewJitteredBackoff(sndr.NewConstantBackoff(0))
+		asserterror.Equal(b.Delay(1), 0, t)
+	})
+}
@@ -2,9 +2,7 @@ package sender
 
 import (
 	"context"
-	"crypto/tls"
 	"errors"
-	"net"
 	"time"
 
 	cmdstream "github.com/cmd-stream/cmd-stream-go"
@@ -15,131 +13,171 @@ import (
 )
 
 // Make creates a new Sender.
+//
+// It is MakeMulti with a single address: at least one client is created, even
+// if WithClientsCount is less than 1, and the client group implements
+// BroadcastGroup. Health of the address is tracked (see WithEndpointHealth),
+// but while all clients are unavailable, Commands are still sent through
+// them.
 func Make[T any](addr string, codec cln.Codec[T],
 	ops ...SetMakeOption[T],
 ) (sender Sender[T], err error) {
-	var connFactory cln.ConnFactoryFn
-	o := MakeOptions[T]{
-		ClientsCount: 1,
+	return MakeMulti([]string{addr}, codec, ops...)
+}
+
+// MakeMulti creates a new Sender that sends Commands to several servers, for
+// example, replicas of the same service.
+//
+// The clients (see WithClientsCount) are distributed evenly across the
+// addresses, at least one per address. Commands are dispatched across all of
+// them according to the dispatch strategy of the group (round-robin by
+// default), skipping clients of unhealthy addresses (see WithEndpointHealth)
+// and closed clients.
+//
+// Connections are established with the dialer (see WithDialer,
+// WithDialContext and WithNetwork), their errors are wrapped in DialError.
+func MakeMulti[T any](addrs []string, codec cln.Codec[T],
+	ops ...SetMakeOption[T],
+) (sender Sender[T], err error) {
+	if len(addrs) == 0 {
+		err = ErrNoAddrs
+		return
 	}
+	o := defaultMakeOptions[T]()
 	ApplyMakeOptitions(ops, &o)
+	if err = o.validate(); err != nil {
+		return
+	}
+	gro := grp.Options[T]{
+		Factory: grp.RoundRobinStrategyFactory[T]{},
+	}
+	grp.ApplyGroup(o.Group, &gro)
 
-	if o.TLSConfig == nil {
-		connFactory = func() (net.Conn, error) {
-			return net.Dial("tcp", addr)
+	var (
+		clients   []grp.Client[T]
+		endpoints []endpointClient[T]
+		names     []string
+	)
+	for i, addr := range addrs {
+		var (
+			e = &endpoint{
+				maxFailures: o.EndpointMaxFailures,
+				cooldown:    o.EndpointCooldown,
+			}
+			count = o.ClientsCount / len(addrs)
+		)
+		if i < o.ClientsCount%len(addrs) {
+			count++
 		}
-	} else {
-		connFactory = func() (net.Conn, error) {
-			return tls.Dial("tcp", addr, o.TLSConfig)
+		for j := range max(count, 1) {
+			var client grp.Client[T]
+			client, err = makeClient(codec, o.connFactory(addr), gro)
+			if err != nil {
+				for i := range clients {
+					clients[i].Close()
+				}
+				err = cmdstream.NewCmdStreamError(err)
+				return
+			}
+			c := newEndpointClient(client, e)
+			clients = append(clients, c)
+			endpoints = append(endpoints, c)
+			names = append(names, clientName(addr, j))
 		}
 	}
-	group, err := cmdstream.MakeClientGroup(o.ClientsCount, codec, connFactory,
-		o.Group...,
-	)
-	if err != nil {
-		group.Close()
-		return
+	group := clientsGroup[T]{
+		grp.NewClientGroup[T](endpointStrategy[T]{gro.Factory.New(clients)}),
+		endpoints,
+	}
+	if o.Sharding != nil {
+		return New(newShardedGroup(group, names, *o.Sharding), o.Sender...), nil
 	}
 	sender = New(group, o.Sender...)
 	return
 }
 
+func makeClient[T any](codec cln.Codec[T], factory cln.ConnFactory,
+	o grp.Options[T],
+) (client grp.Client[T], err error) {
+	if o.Reconnect {
+		return cmdstream.MakeReconnectClient(codec, factory, o.ClientOps...)
+	}
+	conn, err := factory.New()
+	if err != nil {
+		return
+	}
+	return cmdstream.MakeClient(codec, conn, o.ClientOps...)
+}
+
 // New creates a new Sender with the given client group and optional hooks.
 func New[T any](group ClientGroup[T], ops ...SetOption[T]) Sender[T] {
 	o := Options[T]{
-		HooksFactory: hks.NoopHooksFactory[T]{},
+		HooksFactory:     hks.NoopHooksFactory[T]{},
+		StreamBufferSize: 16,
 	}
 	Apply(ops, &o)
 
-	return Sender[T]{
+	s := Sender[T]{
 		group:   group,
 		options: o,
+		tracker: newTracker(),
+	}
+	if o.Cache != nil {
+		s.cache = newCache(*o.Cache)
+	}
+	if o.Coalesce {
+		s.coalescer = newCoalescer()
 	}
+	return s
 }
 
 // Sender provides a high-level abstraction over a client group for sending
 // Commands to the server.
 type Sender[T any] struct {
-	group   ClientGroup[T]
-	options Options[T]
+	group     ClientGroup[T]
+	options   Options[T]
+	tracker   *tracker
+	cache     *cache
+	coalescer *coalescer
 }
 
 // Send sends a Command to the server and waits (using the ctx) for the Result.
+//
+// If WithContextDeadline is set and the ctx has a deadline, the Command is
+// sent with it, as with SendWithDeadline.
+//
+// If the retry is enabled (see WithRetry), failed attempts are repeated
+// according to the retry options. The Command may also be hedged (see
+// WithHedging), coalesced with identical Commands in flight (see
+// WithCoalescing), or its Result served from the cache (see WithCache).
 func (s Sender[T]) Send(ctx context.Context, cmd core.Cmd[T]) (
 	result core.Result, err error,
 ) {
-	var (
-		results = make(chan core.AsyncResult, 1)
-		hooks   = s.options.HooksFactory.New()
-	)
-	ctx, err = hooks.BeforeSend(ctx, cmd)
-	if err != nil {
-		return
-	}
-	seq, clientID, n, err := s.group.Send(cmd, results)
-	sentCmd := hks.SentCmd[T]{
-		Seq:  seq,
-		Size: n,
-		Cmd:  cmd,
-	}
-	if err != nil {
-		hooks.OnError(ctx, sentCmd, err)
-		return
-	}
-	return s.receive(ctx, sentCmd, results, clientID, hooks)
+	return s.send(ctx, cmd, time.Time{})
 }
 
 // SendWithDeadline sends a Command to the server with the specified deadline
 // and waits (using the ctx) for the Result.
+//
+// If the retry is enabled (see WithRetry), failed attempts are repeated
+// according to the retry options. The Command may also be hedged (see
+// WithHedging), coalesced with identical Commands in flight (see
+// WithCoalescing), or its Result served from the cache (see WithCache).
 func (s Sender[T]) SendWithDeadline(ctx context.Context,
 	cmd core.Cmd[T], dealine time.Time,
 ) (result core.Result, err error) {
-	var (
-		results = make(chan core.AsyncResult, 1)
-		hooks   = s.options.HooksFactory.New()
-	)
-	ctx, err = hooks.BeforeSend(ctx, cmd)
-	if err != nil {
-		return
-	}
-	seq, clientID, n, err := s.group.SendWithDeadline(cmd, results, dealine)
-	sentCmd := hks.SentCmd[T]{
-		Seq:  seq,
-		Size: n,
-		Cmd:  cmd,
-	}
-	if err != nil {
-		hooks.OnError(ctx, sentCmd, err)
-		return
-	}
-	return s.receive(ctx, sentCmd, results, clientID, hooks)
+	return s.send(ctx, cmd, dealine)
 }
 
 // SendMulti sends a Command to the server and waits (using the ctx) for multiple
 // Results.
+//
+// If receiving fails (a Result comes with an error, the handler returns an
+// error, or the ctx is done), *MultiResultError is returned.
 func (s Sender[T]) SendMulti(ctx context.Context, cmd core.Cmd[T],
 	resultsCount int, handler ResultHandler,
 ) (err error) {
-	var (
-		results = make(chan core.AsyncResult, resultsCount)
-		hooks   = s.options.HooksFactory.New()
-	)
-	ctx, err = hooks.BeforeSend(ctx, cmd)
-	if err != nil {
-		return
-	}
-	seq, clientID, n, err := s.group.Send(cmd, results)
-	sentCmd := hks.SentCmd[T]{
-		Seq:  seq,
-		Size: n,
-		Cmd:  cmd,
-	}
-	if err != nil {
-		hooks.OnError(ctx, sentCmd, err)
-		return
-	}
-	s.receiveMulti(ctx, sentCmd, results, clientID, hooks, handler)
-	return
+	return s.sendMulti(ctx, cmd, resultsCount, handler, time.Time{})
 }
 
 // SendMultiWithDeadline sends a Command to the server with the specified
@@ -150,43 +188,30 @@ func (s Sender[T]) SendMultiWithDeadline(ctx context.Context,
 	handler ResultHandler,
 	dealine time.Time,
 ) (err error) {
-	var (
-		results = make(chan core.AsyncResult, resultsCount)
-		hooks   = s.options.HooksFactory.New()
-	)
-	ctx, err = hooks.BeforeSend(ctx, cmd)
-	if err != nil {
-		return
-	}
-	seq, clientID, n, err := s.group.SendWithDeadline(cmd, results, dealine)
-	sentCmd := hks.SentCmd[T]{
-		Seq:  seq,
-		Size: n,
-		Cmd:  cmd,
-	}
-	if err != nil {
-		hooks.OnError(ctx, sentCmd, err)
-		return
-	}
-	s.receiveMulti(ctx, sentCmd, results, clientID, hooks, handler)
-	return
+	return s.sendMulti(ctx, cmd, resultsCount, handler, dealine)
 }
 
+// CloseAndWait closes the underlying client group and waits for it to be done.
+// Outstanding Commands are not waited for, see Shutdown.
 func (s Sender[T]) CloseAndWait(timeout time.Duration) (err error) {
 	err = s.Close()
 	if err != nil {
 		return
 	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
 	select {
-	case <-time.NewTimer(timeout).C:
+	case <-timer.C:
 		return errors.New("timeout exceeded")
 	case <-s.Done():
 		return
 	}
 }
 
-// Close closes the underlying client group.
+// Close closes the underlying client group, after that, all send methods
+// return ErrClosed.
 func (s Sender[T]) Close() error {
+	s.tracker.close()
 	return s.group.Close()
 }
 
@@ -196,64 +221,225 @@ func (s Sender[T]) Done() <-chan struct{} {
 	return s.group.Done()
 }
 
-func (s Sender[T]) receive(ctx context.Context, sentCmd hks.SentCmd[T],
-	results <-chan core.AsyncResult,
-	clientID grp.ClientID,
-	hooks hks.Hooks[T],
+func (s Sender[T]) send(ctx context.Context, cmd core.Cmd[T],
+	deadline time.Time,
+) (result core.Result, err error) {
+	if !s.tracker.acquire() {
+		return nil, ErrClosed
+	}
+	defer s.tracker.release()
+	if cacheable, ok := cmd.(Cacheable); ok && s.cache != nil {
+		return s.sendCached(ctx, cmd, cacheable, deadline)
+	}
+	return s.sendUncached(ctx, cmd, deadline)
+}
+
+func (s Sender[T]) sendUncached(ctx context.Context, cmd core.Cmd[T],
+	deadline time.Time,
+) (result core.Result, err error) {
+	if coalescable, ok := cmd.(Coalescable); ok && s.coalescer != nil {
+		return s.sendCoalesced(ctx, cmd, coalescable.CoalesceKey(), deadline)
+	}
+	return s.sendDirect(ctx, cmd, deadline)
+}
+
+func (s Sender[T]) sendDirect(ctx context.Context, cmd core.Cmd[T],
+	deadline time.Time,
 ) (result core.Result, err error) {
+	if s.options.Retry != nil {
+		return s.sendRetry(ctx, cmd, deadline)
+	}
+	return s.sendSingle(ctx, cmd, deadline)
+}
+
+// sendSingle sends the Command with hedging if it is enabled for it.
+func (s Sender[T]) sendSingle(ctx context.Context, cmd core.Cmd[T],
+	deadline time.Time,
+) (result core.Result, err error) {
+	if s.options.Hedge != nil && s.options.Hedge.Hedgeable(cmd) {
+		return s.sendHedged(ctx, cmd, deadline)
+	}
+	return s.sendOnce(ctx, cmd, deadline)
+}
+
+func (s Sender[T]) sendOnce(ctx context.Context, cmd core.Cmd[T],
+	deadline time.Time,
+) (result core.Result, err error) {
+	results := make(chan core.AsyncResult, 1)
+	f, err := s.dispatch(ctx, cmd, results, deadline)
+	if err != nil {
+		return
+	}
+	return s.receive(f, results)
+}
+
+func (s Sender[T]) sendMulti(ctx context.Context, cmd core.Cmd[T],
+	resultsCount int,
+	handler ResultHandler,
+	deadline time.Time,
+) (err error) {
+	if !s.tracker.acquire() {
+		return ErrClosed
+	}
+	defer s.tracker.release()
+	results := make(chan core.AsyncResult, resultsCount)
+	f, err := s.dispatch(ctx, cmd, results, deadline)
+	if err != nil {
+		return
+	}
+	return s.receiveMulti(f, results, resultsCount, handler)
+}
+
+// flight describes a Command that was sent and is waiting for Results.
+type flight[T any] struct {
+	ctx      context.Context
+	sentCmd  hks.SentCmd[T]
+	clientID grp.ClientID
+	hooks    hks.Hooks[T]
+}
+
+// dispatch creates new hooks, calls BeforeSend and sends the Command to the
+// client group. If sending fails, hooks.OnError is called.
+//
+// If the deadline is zero and WithContextDeadline is set, the deadline is
+// derived from the ctx returned by BeforeSend.
+func (s Sender[T]) dispatch(ctx context.Context, cmd core.Cmd[T],
+	results chan<- core.AsyncResult,
+	deadline time.Time,
+) (f flight[T], err error) {
+	return s.dispatchWith(ctx, cmd, deadline,
+		func(deadline time.Time) (core.Seq, grp.ClientID, int, error) {
+			return s.groupSend(cmd, results, deadline)
+		})
+}
+
+// dispatchWith is like dispatch, but sends the Command using the send
+// function.
+func (s Sender[T]) dispatchWith(ctx context.Context, cmd core.Cmd[T],
+	deadline time.Time,
+	send func(deadline time.Time) (seq core.Seq, clientID grp.ClientID, n int,
+		err error),
+) (f flight[T], err error) {
+	f.hooks = s.options.HooksFactory.New()
+	f.ctx, err = f.hooks.BeforeSend(ctx, cmd)
+	if err != nil {
+		return
+	}
+	if deadline.IsZero() && s.options.CtxDeadline {
+		if d, ok := f.ctx.Deadline(); ok {
+			deadline = d.Add(-s.options.DeadlineMargin)
+		}
+	}
+	seq, clientID, n, err := send(deadline)
+	f.sentCmd = hks.SentCmd[T]{
+		Seq:  seq,
+		Size: n,
+		Cmd:  cmd,
+	}
+	f.clientID = clientID
+	if err != nil {
+		f.hooks.OnError(f.ctx, f.sentCmd, err)
+	}
+	return
+}
+
+// groupSend uses ClientGroup.SendWithDeadline if the deadline is set, and
+// ClientGroup.Send otherwise.
+func (s Sender[T]) groupSend(cmd core.Cmd[T], results chan<- core.AsyncResult,
+	deadline time.Time,
+) (seq core.Seq, clientID grp.ClientID, n int, err error) {
+	if deadline.IsZero() {
+		return s.group.Send(cmd, results)
+	}
+	return s.group.SendWithDeadline(cmd, results, deadline)
+}
+
+// abandon is called when the ctx is done, it forgets the Command with
+// ErrCanceled if the ctx was canceled with this cause, and ErrTimeout
+// otherwise.
+func (s Sender[T]) abandon(f flight[T]) (err error) {
+	err = ErrTimeout
+	if context.Cause(f.ctx) == ErrCanceled {
+		err = ErrCanceled
+	}
+	s.forget(f, err)
+	return
+}
+
+// forget reports the error to hooks.OnTimeout and makes the client group to
+// forget the Command.
+func (s Sender[T]) forget(f flight[T], err error) {
+	f.hooks.OnTimeout(f.ctx, f.sentCmd, err)
+	s.group.Forget(f.sentCmd.Seq, f.clientID)
+}
+
+func (s Sender[T]) receive(f flight[T], results <-chan core.AsyncResult) (
+	result core.Result, err error,
+) {
 	select {
-	case <-ctx.Done():
-		err = ErrTimeout
-		hooks.OnTimeout(ctx, sentCmd, err)
-		s.group.Forget(sentCmd.Seq, clientID)
+	case <-f.ctx.Done():
+		err = s.abandon(f)
 	case asyncResult := <-results:
-		recvResult := hks.ReceivedResult{
-			Seq:    core.Seq(1),
-			Size:   asyncResult.BytesRead,
-			Result: asyncResult.Result,
-		}
-		hooks.OnResult(ctx, sentCmd, recvResult, asyncResult.Error)
-		result = asyncResult.Result
-		err = asyncResult.Error
+		result, err = s.onResult(f, asyncResult)
 	}
 	return
 }
 
-func (s Sender[T]) receiveMulti(ctx context.Context, sentCmd hks.SentCmd[T],
-	results <-chan core.AsyncResult,
-	clientID grp.ClientID,
-	hooks hks.Hooks[T],
-	handler ResultHandler,
+// onResult reports the single Result to hooks.OnResult.
+func (s Sender[T]) onResult(f flight[T], asyncResult core.AsyncResult) (
+	result core.Result, err error,
 ) {
+	recvResult := hks.ReceivedResult{
+		Seq:    core.Seq(1),
+		Size:   asyncResult.BytesRead,
+		Result: asyncResult.Result,
+	}
+	f.hooks.OnResult(f.ctx, f.sentCmd, recvResult, asyncResult.Error)
+	return asyncResult.Result, asyncResult.Error
+}
+
+func (s Sender[T]) receiveMulti(f flight[T], results <-chan core.AsyncResult,
+	resultsCount int,
+	handler ResultHandler,
+) (err error) {
 	var (
-		result    core.Result
-		handleErr error
-		err       error
-		i         = 1
+		result   core.Result
+		source   FailureSource
+		received int
 	)
 	for {
 		select {
-		case <-ctx.Done():
-			err = ErrTimeout
-			hooks.OnTimeout(ctx, sentCmd, err)
-			s.group.Forget(sentCmd.Seq, clientID)
+		case <-f.ctx.Done():
+			result, err, source = nil, s.abandon(f), SourceTimeout
 		case asyncResult := <-results:
+			received++
 			recvResult := hks.ReceivedResult{
-				Seq:    core.Seq(i),
+				Seq:    core.Seq(received),
 				Size:   asyncResult.BytesRead,
 				Result: asyncResult.Result,
 			}
-			hooks.OnResult(ctx, sentCmd, recvResult, asyncResult.Error)
-			result = asyncResult.Result
-			err = asyncResult.Error
+			f.hooks.OnResult(f.ctx, f.sentCmd, recvResult, asyncResult.Error)
+			result, err, source = asyncResult.Result, asyncResult.Error,
+				SourceServer
+		}
+		if handleErr := handler.Handle(result, err); handleErr != nil &&
+			handleErr != err {
+			if err == nil && !result.LastOne() {
+				s.forget(f, ErrCanceled)
+			}
+			err, source = handleErr, SourceHandler
 		}
-		handleErr = handler.Handle(result, err)
-		if handleErr != nil {
-			err = handleErr
+		if err != nil {
+			return &MultiResultError{
+				Received: received,
+				Expected: resultsCount,
+				Source:   source,
+				LastSeq:  core.Seq(received),
+				Err:      err,
+			}
 		}
-		if err != nil || result.LastOne() {
+		if result.LastOne() {
 			return
 		}
-		i++
 	}
 }
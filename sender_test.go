@@ -330,7 +330,13 @@ func TestSender(t *testing.T) {
 					CmdSize:    10,
 					CmdSendErr: nil,
 
-					Err: nil,
+					Err: &sndr.MultiResultError{
+						Received: 1,
+						Expected: 1,
+						Source:   sndr.SourceTimeout,
+						LastSeq:  core.Seq(1),
+						Err:      sndr.ErrTimeout,
+					},
 				}
 				group = mocks.NewClientGroup().RegisterSend(
 					func(cmd core.Cmd[any], results chan<- core.AsyncResult) (
@@ -493,7 +499,13 @@ func TestSender(t *testing.T) {
 					CmdSize:    10,
 					CmdSendErr: nil,
 
-					Err: nil,
+					Err: &sndr.MultiResultError{
+						Received: 1,
+						Expected: 1,
+						Source:   sndr.SourceTimeout,
+						LastSeq:  core.Seq(1),
+						Err:      sndr.ErrTimeout,
+					},
 				}
 				wantDeadline = time.Now()
 				group        = mocks.NewClientGroup().RegisterSendWithDeadline(
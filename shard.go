@@ -0,0 +1,128 @@
+package sender
+
+import (
+	"cmp"
+	"hash/fnv"
+	"slices"
+	"strconv"
+
+	grp "github.com/cmd-stream/cmd-stream-go/group"
+)
+
+// Keyed is implemented by Commands that must be sent through the same client
+// as all other Commands with the same key, for example, to preserve their
+// order. Used with WithSharding.
+type Keyed interface {
+	Key() string
+}
+
+// HashFn hashes keys and client names onto the consistent hash ring.
+type HashFn func(data []byte) uint64
+
+// DefaultHash is 64-bit FNV-1a with the final mixing step of MurmurHash3,
+// which spreads similar strings, like virtual node names, across the ring.
+func DefaultHash(data []byte) uint64 {
+	h := fnv.New64a()
+	h.Write(data)
+	x := h.Sum64()
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+	return x
+}
+
+type ShardingOptions struct {
+	VirtualNodes int
+	Hash         HashFn
+}
+
+type SetShardingOption func(o *ShardingOptions)
+
+// WithVirtualNodes sets the number of points each client occupies on the
+// ring. More points give a more even distribution of keys. The default is
+// 100.
+func WithVirtualNodes(count int) SetShardingOption {
+	return func(o *ShardingOptions) { o.VirtualNodes = count }
+}
+
+// WithHash sets the hash function. The default is DefaultHash.
+func WithHash(fn HashFn) SetShardingOption {
+	return func(o *ShardingOptions) { o.Hash = fn }
+}
+
+func ApplySharding(ops []SetShardingOption, o *ShardingOptions) {
+	for i := range ops {
+		if ops[i] != nil {
+			ops[i](o)
+		}
+	}
+}
+
+// hashRing maps keys onto clients using consistent hashing, so that adding or
+// removing a client remaps only the keys of its neighbours on the ring.
+type hashRing struct {
+	options ShardingOptions
+	points  []ringPoint
+}
+
+type ringPoint struct {
+	hash     uint64
+	clientID grp.ClientID
+}
+
+func newHashRing(o ShardingOptions) *hashRing {
+	return &hashRing{options: o}
+}
+
+// add places the client on the ring. The name identifies the client, clients
+// with the same name occupy the same points.
+func (r *hashRing) add(clientID grp.ClientID, name string) {
+	for i := range max(r.options.VirtualNodes, 1) {
+		r.points = append(r.points, ringPoint{
+			hash:     r.options.Hash([]byte(name + "#" + strconv.Itoa(i))),
+			clientID: clientID,
+		})
+	}
+	slices.SortFunc(r.points, func(a, b ringPoint) int {
+		if a.hash != b.hash {
+			return cmp.Compare(a.hash, b.hash)
+		}
+		return cmp.Compare(a.clientID, b.clientID)
+	})
+}
+
+func (r *hashRing) remove(clientID grp.ClientID) {
+	r.points = slices.DeleteFunc(r.points, func(p ringPoint) bool {
+		return p.clientID == clientID
+	})
+}
+
+// lookup returns the client of the key: the first one clockwise from the key
+// hash for which the available function returns true. If there is no such
+// client, the first one is returned. If the ring is empty, ok == false.
+func (r *hashRing) lookup(key string, available func(grp.ClientID) bool) (
+	clientID grp.ClientID, ok bool,
+) {
+	if len(r.points) == 0 {
+		return
+	}
+	var (
+		hash = r.options.Hash([]byte(key))
+		i, _ = slices.BinarySearchFunc(r.points, hash,
+			func(p ringPoint, hash uint64) int { return cmp.Compare(p.hash, hash) })
+	)
+	for j := range len(r.points) {
+		p := r.points[(i+j)%len(r.points)]
+		if available(p.clientID) {
+			return p.clientID, true
+		}
+	}
+	return r.points[i%len(r.points)].clientID, true
+}
+
+// clientName identifies the i-th client of the address on the ring.
+func clientName(addr string, i int) string {
+	return addr + "/" + strconv.Itoa(i)
+}
@@ -0,0 +1,123 @@
+package sender_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	sndr "github.com/cmd-stream/sender-go"
+	"github.com/cmd-stream/sender-go/resolver"
+	"github.com/cmd-stream/sender-go/test/helpers"
+	asserterror "github.com/ymz-ncnk/assert/error"
+	assertfatal "github.com/ymz-ncnk/assert/fatal"
+)
+
+type keyedCmd struct {
+	helpers.AddrCmd
+	key string
+}
+
+func (c keyedCmd) Key() string { return c.key }
+
+func TestSharding(t *testing.T) {
+	t.Run("Should send Commands with the same key to the same address",
+		func(t *testing.T) {
+			addr1, server1, err := helpers.StartServer()
+			assertfatal.EqualError(err, nil, t)
+			defer server1.Close()
+			addr2, server2, err := helpers.StartServer()
+			assertfatal.EqualError(err, nil, t)
+			defer server2.Close()
+
+			sender, err := sndr.MakeMulti([]string{addr1, addr2},
+				helpers.ClientCodec{}, sndr.WithClientsCount[struct{}](4),
+				sndr.WithSharding[struct{}]())
+			assertfatal.EqualError(err, nil, t)
+			defer sender.Close()
+
+			routes := sendKeys(t, sender, 50)
+			asserterror.Equal(len(countAddrs(routes)), 2, t)
+			asserterror.EqualDeep(sendKeys(t, sender, 50), routes, t)
+		})
+
+	t.Run("Should move only the keys of the lost address", func(t *testing.T) {
+		addr1, server1, err := helpers.StartServer()
+		assertfatal.EqualError(err, nil, t)
+		defer server1.Close()
+		addr2, server2, err := helpers.StartServer()
+		assertfatal.EqualError(err, nil, t)
+		defer server2.Close()
+		addr3, server3, err := helpers.StartServer()
+		assertfatal.EqualError(err, nil, t)
+
+		sender, err := sndr.MakeMulti([]string{addr1, addr2, addr3},
+			helpers.ClientCodec{}, sndr.WithSharding[struct{}](),
+			sndr.WithEndpointHealth[struct{}](1, time.Minute))
+		assertfatal.EqualError(err, nil, t)
+		defer sender.Close()
+
+		before := sendKeys(t, sender, 50)
+		server3.Close()
+		time.Sleep(100 * time.Millisecond)
+		after := sendKeys(t, sender, 50)
+		for key, addr := range before {
+			if addr != helpers.AddrResult(addr3) {
+				asserterror.Equal(after[key], addr, t)
+			}
+		}
+		asserterror.Equal(countAddrs(after)[helpers.AddrResult(addr3)], 0, t)
+	})
+
+	t.Run("Should move only the keys of the added address", func(t *testing.T) {
+		addr1, server1, err := helpers.StartServer()
+		assertfatal.EqualError(err, nil, t)
+		defer server1.Close()
+		addr2, server2, err := helpers.StartServer()
+		assertfatal.EqualError(err, nil, t)
+		defer server2.Close()
+
+		r := resolver.NewFake(addr1)
+		sender, err := sndr.MakeResolved(context.Background(), r,
+			helpers.ClientCodec{}, sndr.WithSharding[struct{}](
+				sndr.WithVirtualNodes(50)))
+		assertfatal.EqualError(err, nil, t)
+		defer sender.Close()
+
+		before := sendKeys(t, sender, 50)
+		r.Set(addr1, addr2)
+		var after map[string]helpers.AddrResult
+		eventually(t, func() bool {
+			after = sendKeys(t, sender, 50)
+			return len(countAddrs(after)) == 2
+		})
+		for key, addr := range after {
+			if addr != helpers.AddrResult(addr2) {
+				asserterror.Equal(addr, before[key], t)
+			}
+		}
+	})
+}
+
+func sendKeys(t *testing.T, sender sndr.Sender[struct{}], n int) (
+	routes map[string]helpers.AddrResult,
+) {
+	routes = map[string]helpers.AddrResult{}
+	for i := range n {
+		key := "key-" + strconv.Itoa(i)
+		result, err := sender.Send(context.Background(), keyedCmd{key: key})
+		assertfatal.EqualError(err, nil, t)
+		routes[key] = result.(helpers.AddrResult)
+	}
+	return
+}
+
+func countAddrs(routes map[string]helpers.AddrResult) (
+	counts map[helpers.AddrResult]int,
+) {
+	counts = map[helpers.AddrResult]int{}
+	for _, addr := range routes {
+		counts[addr]++
+	}
+	return
+}
@@ -0,0 +1,60 @@
+package sender
+
+import (
+	"time"
+
+	grp "github.com/cmd-stream/cmd-stream-go/group"
+	"github.com/cmd-stream/core-go"
+)
+
+func newShardedGroup[T any](group clientsGroup[T], names []string,
+	o ShardingOptions,
+) shardedGroup[T] {
+	ring := newHashRing(o)
+	for i := range group.clients {
+		ring.add(grp.ClientID(i), names[i])
+	}
+	return shardedGroup[T]{group, ring}
+}
+
+// shardedGroup sends Keyed Commands through the client chosen by the
+// consistent hash ring, and all other Commands through the wrapped group.
+type shardedGroup[T any] struct {
+	clientsGroup[T]
+	ring *hashRing
+}
+
+func (g shardedGroup[T]) Send(cmd core.Cmd[T],
+	results chan<- core.AsyncResult,
+) (seq core.Seq, clientID grp.ClientID, n int, err error) {
+	clientID, ok := g.route(cmd)
+	if !ok {
+		return g.clientsGroup.Send(cmd, results)
+	}
+	seq, n, err = g.SendTo(clientID, cmd, results)
+	return
+}
+
+func (g shardedGroup[T]) SendWithDeadline(cmd core.Cmd[T],
+	results chan<- core.AsyncResult,
+	deadline time.Time,
+) (seq core.Seq, clientID grp.ClientID, n int, err error) {
+	clientID, ok := g.route(cmd)
+	if !ok {
+		return g.clientsGroup.SendWithDeadline(cmd, results, deadline)
+	}
+	seq, n, err = g.SendToWithDeadline(clientID, cmd, results, deadline)
+	return
+}
+
+func (g shardedGroup[T]) route(cmd core.Cmd[T]) (clientID grp.ClientID,
+	ok bool,
+) {
+	keyed, ok := cmd.(Keyed)
+	if !ok {
+		return
+	}
+	return g.ring.lookup(keyed.Key(), func(id grp.ClientID) bool {
+		return g.clients[id].available()
+	})
+}
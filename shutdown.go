@@ -0,0 +1,89 @@
+package sender
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// Shutdown gracefully closes the Sender. It stops accepting new Commands (all
+// send methods return ErrClosed), waits (using the ctx) for the outstanding
+// ones to complete, including all Results of multi-result Commands, and then
+// closes the client group and waits for it to be done.
+//
+// If the ctx is done first, the group is closed anyway, the still outstanding
+// Commands fail, and their number is returned as abandoned along with the ctx
+// error.
+func (s Sender[T]) Shutdown(ctx context.Context) (abandoned int, err error) {
+	idle := s.tracker.close()
+	select {
+	case <-ctx.Done():
+		abandoned = s.tracker.count()
+		err = ctx.Err()
+	case <-idle:
+	}
+	if cerr := s.group.Close(); cerr != nil {
+		return abandoned, errors.Join(err, cerr)
+	}
+	if err != nil {
+		return
+	}
+	select {
+	case <-ctx.Done():
+		err = ctx.Err()
+	case <-s.group.Done():
+	}
+	return
+}
+
+func newTracker() *tracker {
+	return &tracker{idle: make(chan struct{})}
+}
+
+// tracker counts outstanding Commands, and once closed, rejects new ones.
+type tracker struct {
+	mu          sync.Mutex
+	closed      bool
+	outstanding int
+	idle        chan struct{}
+}
+
+// acquire registers a new Command, returns false if the tracker is closed.
+func (t *tracker) acquire() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closed {
+		return false
+	}
+	t.outstanding++
+	return true
+}
+
+func (t *tracker) release() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.outstanding--
+	if t.closed && t.outstanding == 0 {
+		close(t.idle)
+	}
+}
+
+// close rejects new Commands and returns a channel that is closed when there
+// are no outstanding ones. It may be called several times.
+func (t *tracker) close() <-chan struct{} {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.closed {
+		t.closed = true
+		if t.outstanding == 0 {
+			close(t.idle)
+		}
+	}
+	return t.idle
+}
+
+func (t *tracker) count() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.outstanding
+}
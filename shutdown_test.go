@@ -0,0 +1,96 @@
+package sender_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	grp "github.com/cmd-stream/cmd-stream-go/group"
+	"github.com/cmd-stream/core-go"
+	sndr "github.com/cmd-stream/sender-go"
+	"github.com/cmd-stream/sender-go/test/mocks"
+	cmocks "github.com/cmd-stream/testkit-go/mocks/core"
+	asserterror "github.com/ymz-ncnk/assert/error"
+	"github.com/ymz-ncnk/mok"
+)
+
+func TestShutdown(t *testing.T) {
+	t.Run("Should wait for outstanding Commands", func(t *testing.T) {
+		var (
+			wantResult = cmocks.NewResult()
+			sent       = make(chan chan<- core.AsyncResult, 1)
+			done       = make(chan struct{})
+			group      = mocks.NewClientGroup().RegisterSend(
+				func(cmd core.Cmd[any], results chan<- core.AsyncResult) (
+					seq core.Seq, clientID grp.ClientID, n int, err error,
+				) {
+					sent <- results
+					return 1, 0, 10, nil
+				},
+			).RegisterClose(
+				func() error { close(done); return nil },
+			).RegisterDone(
+				func() <-chan struct{} { return done },
+			)
+			sender = sndr.New[any](group)
+			mocks  = []*mok.Mock{group.Mock}
+			errs   = make(chan error, 1)
+		)
+		go func() {
+			_, err := sender.Send(context.Background(), cmocks.NewCmd())
+			errs <- err
+		}()
+		results := <-sent
+
+		shutdown := make(chan int, 1)
+		go func() {
+			abandoned, err := sender.Shutdown(context.Background())
+			asserterror.EqualError(err, nil, t)
+			shutdown <- abandoned
+		}()
+		time.Sleep(100 * time.Millisecond)
+		_, err := sender.Send(context.Background(), cmocks.NewCmd())
+		asserterror.EqualError(err, sndr.ErrClosed, t)
+		select {
+		case <-shutdown:
+			t.Fatal("Shutdown did not wait for the outstanding Command")
+		default:
+		}
+
+		results <- core.AsyncResult{Result: wantResult}
+		asserterror.EqualError(<-errs, nil, t)
+		asserterror.Equal(<-shutdown, 0, t)
+
+		asserterror.EqualDeep(mok.CheckCalls(mocks), mok.EmptyInfomap, t)
+	})
+
+	t.Run("Should report abandoned Commands if the ctx is done",
+		func(t *testing.T) {
+			var (
+				group = mocks.NewClientGroup().RegisterSend(
+					func(cmd core.Cmd[any], results chan<- core.AsyncResult) (
+						seq core.Seq, clientID grp.ClientID, n int, err error,
+					) {
+						return 1, 0, 10, nil
+					},
+				).RegisterClose(
+					func() error { return nil },
+				)
+				sender      = sndr.New[any](group)
+				mocks       = []*mok.Mock{group.Mock}
+				ctx, cancel = context.WithTimeout(context.Background(),
+					100*time.Millisecond)
+			)
+			defer cancel()
+			future := sender.SendAsync(context.Background(), cmocks.NewCmd())
+
+			abandoned, err := sender.Shutdown(ctx)
+			asserterror.EqualError(err, context.DeadlineExceeded, t)
+			asserterror.Equal(abandoned, 1, t)
+
+			asserterror.EqualDeep(mok.CheckCalls(mocks), mok.EmptyInfomap, t)
+			group.RegisterForget(func(seq core.Seq, clientID grp.ClientID) {})
+			future.Cancel()
+			<-future.Done()
+		})
+}
@@ -0,0 +1,78 @@
+package sender
+
+import (
+	"context"
+	"iter"
+	"time"
+
+	"github.com/cmd-stream/core-go"
+	hks "github.com/cmd-stream/sender-go/hooks"
+)
+
+// SendStream returns an iterator that sends a Command to the server and
+// yields its Results as they arrive, until the one for which LastOne() returns
+// true.
+//
+// The Command is sent each time the iteration starts. Any error ends the
+// iteration, it is yielded with a nil (or the received) Result. If the ctx is
+// done, ErrTimeout is yielded. If the iteration is stopped early, the Command
+// is forgotten and hooks.OnTimeout is called with ErrCanceled.
+func (s Sender[T]) SendStream(ctx context.Context, cmd core.Cmd[T]) (
+	results iter.Seq2[core.Result, error],
+) {
+	return s.stream(ctx, cmd, time.Time{})
+}
+
+// SendStreamWithDeadline is like SendStream, but sends the Command with the
+// specified deadline.
+func (s Sender[T]) SendStreamWithDeadline(ctx context.Context, cmd core.Cmd[T],
+	deadline time.Time,
+) (results iter.Seq2[core.Result, error]) {
+	return s.stream(ctx, cmd, deadline)
+}
+
+func (s Sender[T]) stream(ctx context.Context, cmd core.Cmd[T],
+	deadline time.Time,
+) iter.Seq2[core.Result, error] {
+	return func(yield func(core.Result, error) bool) {
+		if !s.tracker.acquire() {
+			yield(nil, ErrClosed)
+			return
+		}
+		defer s.tracker.release()
+		results := make(chan core.AsyncResult, s.options.StreamBufferSize)
+		f, err := s.dispatch(ctx, cmd, results, deadline)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		for i := 1; ; i++ {
+			select {
+			case <-f.ctx.Done():
+				yield(nil, s.abandon(f))
+				return
+			case asyncResult := <-results:
+				recvResult := hks.ReceivedResult{
+					Seq:    core.Seq(i),
+					Size:   asyncResult.BytesRead,
+					Result: asyncResult.Result,
+				}
+				f.hooks.OnResult(f.ctx, f.sentCmd, recvResult, asyncResult.Error)
+				if asyncResult.Error != nil {
+					yield(asyncResult.Result, asyncResult.Error)
+					return
+				}
+				lastOne := asyncResult.Result.LastOne()
+				if !yield(asyncResult.Result, nil) {
+					if !lastOne {
+						s.forget(f, ErrCanceled)
+					}
+					return
+				}
+				if lastOne {
+					return
+				}
+			}
+		}
+	}
+}
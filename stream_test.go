@@ -0,0 +1,207 @@
+package sender_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	grp "github.com/cmd-stream/cmd-stream-go/group"
+	"github.com/cmd-stream/core-go"
+	sndr "github.com/cmd-stream/sender-go"
+	hks "github.com/cmd-stream/sender-go/hooks"
+	"github.com/cmd-stream/sender-go/test/mocks"
+	cmocks "github.com/cmd-stream/testkit-go/mocks/core"
+	asserterror "github.com/ymz-ncnk/assert/error"
+	"github.com/ymz-ncnk/mok"
+)
+
+func TestSendStream(t *testing.T) {
+	t.Run("Should yield all Results", func(t *testing.T) {
+		var (
+			wantResults = []core.Result{
+				cmocks.NewResult().RegisterLastOne(func() bool { return false }),
+				cmocks.NewResult().RegisterLastOne(func() bool { return true }),
+			}
+			group = mocks.NewClientGroup().RegisterSend(
+				func(cmd core.Cmd[any], results chan<- core.AsyncResult) (
+					seq core.Seq, clientID grp.ClientID, n int, err error,
+				) {
+					for i := range wantResults {
+						results <- core.AsyncResult{Seq: 1, BytesRead: i + 1,
+							Result: wantResults[i]}
+					}
+					return 1, 0, 10, nil
+				},
+			)
+			hooks = mocks.NewHooks[any]().RegisterBeforeSend(
+				func(ctx context.Context, cmd core.Cmd[any]) (context.Context, error) {
+					return ctx, nil
+				},
+			)
+			factory = mocks.NewHooksFactory[any]().RegisterNew(
+				func() hks.Hooks[any] { return hooks },
+			)
+			sender = sndr.New(group, sndr.WithHooksFactory[any](factory))
+			mocks  = []*mok.Mock{group.Mock, hooks.Mock, factory.Mock}
+		)
+		for i := range wantResults {
+			hooks.RegisterOnResult(
+				func(ctx context.Context, sentCmd hks.SentCmd[any],
+					recvResult hks.ReceivedResult, err error,
+				) {
+					asserterror.EqualDeep(recvResult, hks.ReceivedResult{
+						Seq:    core.Seq(i + 1),
+						Size:   i + 1,
+						Result: wantResults[i],
+					}, t)
+				},
+			)
+		}
+		var results []core.Result
+		for result, err := range sender.SendStream(context.Background(),
+			cmocks.NewCmd()) {
+			asserterror.EqualError(err, nil, t)
+			results = append(results, result)
+		}
+		asserterror.EqualDeep(results, wantResults, t)
+
+		asserterror.EqualDeep(mok.CheckCalls(mocks), mok.EmptyInfomap, t)
+	})
+
+	t.Run("Should treat a negative buffer size as 0", func(t *testing.T) {
+		var (
+			wantResult = cmocks.NewResult().RegisterLastOne(
+				func() bool { return true },
+			)
+			group = mocks.NewClientGroup().RegisterSend(
+				func(cmd core.Cmd[any], results chan<- core.AsyncResult) (
+					seq core.Seq, clientID grp.ClientID, n int, err error,
+				) {
+					go func() {
+						results <- core.AsyncResult{Seq: 1, Result: wantResult}
+					}()
+					return 1, 0, 10, nil
+				},
+			)
+			sender = sndr.New(group, sndr.WithStreamBufferSize[any](-1))
+			mocks  = []*mok.Mock{group.Mock, wantResult.Mock}
+		)
+		var results []core.Result
+		for result, err := range sender.SendStream(context.Background(),
+			cmocks.NewCmd()) {
+			asserterror.EqualError(err, nil, t)
+			results = append(results, result)
+		}
+		asserterror.EqualDeep(results, []core.Result{wantResult}, t)
+
+		asserterror.EqualDeep(mok.CheckCalls(mocks), mok.EmptyInfomap, t)
+	})
+
+	t.Run("Break should forget the Command", func(t *testing.T) {
+		var (
+			wantSeq      core.Seq     = 3
+			wantClientID grp.ClientID = 2
+			wantDeadline              = time.Now().Add(time.Second)
+			group                     = mocks.NewClientGroup().RegisterSendWithDeadline(
+				func(cmd core.Cmd[any], results chan<- core.AsyncResult,
+					deadline time.Time,
+				) (seq core.Seq, clientID grp.ClientID, n int, err error) {
+					asserterror.Equal(deadline, wantDeadline, t)
+					results <- core.AsyncResult{Result: cmocks.NewResult().RegisterLastOne(
+						func() bool { return false },
+					)}
+					return wantSeq, wantClientID, 10, nil
+				},
+			).RegisterForget(
+				func(seq core.Seq, clientID grp.ClientID) {
+					asserterror.Equal(seq, wantSeq, t)
+					asserterror.Equal(clientID, wantClientID, t)
+				},
+			)
+			hooks = mocks.NewHooks[any]().RegisterBeforeSend(
+				func(ctx context.Context, cmd core.Cmd[any]) (context.Context, error) {
+					return ctx, nil
+				},
+			).RegisterOnResult(
+				func(ctx context.Context, sentCmd hks.SentCmd[any],
+					recvResult hks.ReceivedResult, err error,
+				) {
+				},
+			).RegisterOnTimeout(
+				func(ctx context.Context, sentCmd hks.SentCmd[any], err error) {
+					asserterror.EqualError(err, sndr.ErrCanceled, t)
+				},
+			)
+			factory = mocks.NewHooksFactory[any]().RegisterNew(
+				func() hks.Hooks[any] { return hooks },
+			)
+			sender = sndr.New(group, sndr.WithHooksFactory[any](factory))
+			mocks  = []*mok.Mock{group.Mock, hooks.Mock, factory.Mock}
+		)
+		for range sender.SendStreamWithDeadline(context.Background(),
+			cmocks.NewCmd(), wantDeadline) {
+			break
+		}
+		asserterror.EqualDeep(mok.CheckCalls(mocks), mok.EmptyInfomap, t)
+	})
+
+	t.Run("Should yield ErrTimeout if the ctx is done", func(t *testing.T) {
+		var (
+			ctx, cancel = context.WithCancel(context.Background())
+			group       = mocks.NewClientGroup().RegisterSend(
+				func(cmd core.Cmd[any], results chan<- core.AsyncResult) (
+					seq core.Seq, clientID grp.ClientID, n int, err error,
+				) {
+					cancel()
+					return 1, 0, 10, nil
+				},
+			).RegisterForget(func(seq core.Seq, clientID grp.ClientID) {})
+			sender = sndr.New[any](group)
+			mocks  = []*mok.Mock{group.Mock}
+			errs   []error
+		)
+		for result, err := range sender.SendStream(ctx, cmocks.NewCmd()) {
+			asserterror.Equal(result, nil, t)
+			errs = append(errs, err)
+		}
+		asserterror.EqualDeep(errs, []error{sndr.ErrTimeout}, t)
+
+		asserterror.EqualDeep(mok.CheckCalls(mocks), mok.EmptyInfomap, t)
+	})
+
+	t.Run("Should yield ClientGroup.Send error", func(t *testing.T) {
+		var (
+			wantErr = errors.New("ClientGroup.Send error")
+			group   = mocks.NewClientGroup().RegisterSend(
+				func(cmd core.Cmd[any], results chan<- core.AsyncResult) (
+					seq core.Seq, clientID grp.ClientID, n int, err error,
+				) {
+					return 0, 0, 0, wantErr
+				},
+			)
+			hooks = mocks.NewHooks[any]().RegisterBeforeSend(
+				func(ctx context.Context, cmd core.Cmd[any]) (context.Context, error) {
+					return ctx, nil
+				},
+			).RegisterOnError(
+				func(ctx context.Context, sentCmd hks.SentCmd[any], err error) {
+					asserterror.EqualError(err, wantErr, t)
+				},
+			)
+			factory = mocks.NewHooksFactory[any]().RegisterNew(
+				func() hks.Hooks[any] { return hooks },
+			)
+			sender = sndr.New(group, sndr.WithHooksFactory[any](factory))
+			mocks  = []*mok.Mock{group.Mock, hooks.Mock, factory.Mock}
+			errs   []error
+		)
+		for _, err := range sender.SendStream(context.Background(),
+			cmocks.NewCmd()) {
+			errs = append(errs, err)
+		}
+		asserterror.EqualDeep(errs, []error{wantErr}, t)
+
+		asserterror.EqualDeep(mok.CheckCalls(mocks), mok.EmptyInfomap, t)
+	})
+}
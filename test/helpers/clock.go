@@ -0,0 +1,29 @@
+package helpers
+
+import (
+	"sync"
+	"time"
+)
+
+// NewClock creates a new Clock set to the specified time.
+func NewClock(now time.Time) *Clock {
+	return &Clock{now: now}
+}
+
+// Clock is a manually advanced clock.
+type Clock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	c.mu.Unlock()
+}
@@ -0,0 +1,99 @@
+package helpers
+
+import (
+	"context"
+	"io"
+	"net"
+	"time"
+
+	cmdstream "github.com/cmd-stream/cmd-stream-go"
+	srv "github.com/cmd-stream/cmd-stream-go/server"
+	"github.com/cmd-stream/core-go"
+	csrv "github.com/cmd-stream/core-go/server"
+	"github.com/cmd-stream/transport-go"
+)
+
+// StartServer starts a cmd-stream server on a random local port. The server
+// replies to AddrCmd with its address.
+func StartServer() (addr string, server *csrv.Server, err error) {
+	return StartServerOn("tcp", "127.0.0.1:0")
+}
+
+// StartServerOn is like StartServer, but listens on the specified network and
+// address.
+func StartServerOn(network, addr string) (laddr string, server *csrv.Server,
+	err error,
+) {
+	listener, err := net.Listen(network, addr)
+	if err != nil {
+		return
+	}
+	server = cmdstream.MakeServer[struct{}](ServerCodec{},
+		srv.NewInvoker(struct{}{}))
+	go server.Serve(listener.(core.Listener))
+	return listener.Addr().String(), server, nil
+}
+
+// AddrCmd asks the server for its address.
+type AddrCmd struct{}
+
+func (c AddrCmd) Exec(ctx context.Context, seq core.Seq, at time.Time,
+	receiver struct{}, proxy core.Proxy,
+) (err error) {
+	_, err = proxy.Send(seq, AddrResult(proxy.LocalAddr().String()))
+	return
+}
+
+// AddrResult is the address of the server.
+type AddrResult string
+
+func (r AddrResult) LastOne() bool { return true }
+
+// ClientCodec encodes AddrCmd and decodes AddrResult.
+type ClientCodec struct{}
+
+func (c ClientCodec) Encode(cmd core.Cmd[struct{}], w transport.Writer) (
+	n int, err error,
+) {
+	if err = w.WriteByte(0); err != nil {
+		return
+	}
+	return 1, nil
+}
+
+func (c ClientCodec) Decode(r transport.Reader) (result core.Result, n int,
+	err error,
+) {
+	l, err := r.ReadByte()
+	if err != nil {
+		return
+	}
+	bs := make([]byte, l)
+	if _, err = io.ReadFull(r, bs); err != nil {
+		return
+	}
+	return AddrResult(bs), int(l) + 1, nil
+}
+
+// ServerCodec decodes AddrCmd and encodes AddrResult.
+type ServerCodec struct{}
+
+func (c ServerCodec) Encode(result core.Result, w transport.Writer) (
+	n int, err error,
+) {
+	addr := result.(AddrResult)
+	if err = w.WriteByte(byte(len(addr))); err != nil {
+		return
+	}
+	n, err = w.WriteString(string(addr))
+	return n + 1, err
+}
+
+func (c ServerCodec) Decode(r transport.Reader) (cmd core.Cmd[struct{}],
+	n int, err error,
+) {
+	if _, err = r.ReadByte(); err != nil {
+		return
+	}
+	return AddrCmd{}, 1, nil
+}
@@ -0,0 +1,97 @@
+package mocks
+
+import (
+	"time"
+
+	grp "github.com/cmd-stream/cmd-stream-go/group"
+	"github.com/cmd-stream/core-go"
+	"github.com/ymz-ncnk/mok"
+)
+
+type (
+	ClientIDsFn func() []grp.ClientID
+	SendToFn    func(clientID grp.ClientID, cmd core.Cmd[any],
+		results chan<- core.AsyncResult) (seq core.Seq, n int, err error)
+	SendToWithDeadlineFn func(clientID grp.ClientID, cmd core.Cmd[any],
+		results chan<- core.AsyncResult, deadline time.Time) (seq core.Seq,
+		n int, err error)
+)
+
+func NewBroadcastGroup() BroadcastGroup {
+	return BroadcastGroup{
+		ClientGroup: ClientGroup{Mock: mok.New("BroadcastGroup")},
+	}
+}
+
+type BroadcastGroup struct {
+	ClientGroup
+}
+
+func (g BroadcastGroup) RegisterClientIDs(fn ClientIDsFn) BroadcastGroup {
+	g.Register("ClientIDs", fn)
+	return g
+}
+
+func (g BroadcastGroup) RegisterSendTo(fn SendToFn) BroadcastGroup {
+	g.Register("SendTo", fn)
+	return g
+}
+
+func (g BroadcastGroup) RegisterNSendTo(n int, fn SendToFn) BroadcastGroup {
+	g.RegisterN("SendTo", n, fn)
+	return g
+}
+
+func (g BroadcastGroup) RegisterSendToWithDeadline(
+	fn SendToWithDeadlineFn,
+) BroadcastGroup {
+	g.Register("SendToWithDeadline", fn)
+	return g
+}
+
+func (g BroadcastGroup) RegisterForget(fn ForgetFn) BroadcastGroup {
+	g.Register("Forget", fn)
+	return g
+}
+
+func (g BroadcastGroup) ClientIDs() (ids []grp.ClientID) {
+	result, err := g.Call("ClientIDs")
+	if err != nil {
+		panic(err)
+	}
+
+	ids, _ = result[0].([]grp.ClientID)
+	return
+}
+
+func (g BroadcastGroup) SendTo(clientID grp.ClientID, cmd core.Cmd[any],
+	results chan<- core.AsyncResult,
+) (seq core.Seq, n int, err error) {
+	result, err := g.Call("SendTo", clientID, mok.SafeVal[core.Cmd[any]](cmd),
+		results)
+	if err != nil {
+		panic(err)
+	}
+
+	seq = result[0].(core.Seq)
+	n = result[1].(int)
+	err, _ = result[2].(error)
+	return
+}
+
+func (g BroadcastGroup) SendToWithDeadline(clientID grp.ClientID,
+	cmd core.Cmd[any],
+	results chan<- core.AsyncResult,
+	deadline time.Time,
+) (seq core.Seq, n int, err error) {
+	result, err := g.Call("SendToWithDeadline", clientID,
+		mok.SafeVal[core.Cmd[any]](cmd), results, deadline)
+	if err != nil {
+		panic(err)
+	}
+
+	seq = result[0].(core.Seq)
+	n = result[1].(int)
+	err, _ = result[2].(error)
+	return
+}
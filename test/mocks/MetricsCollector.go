@@ -0,0 +1,137 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/ymz-ncnk/mok"
+)
+
+type (
+	MetricsCollectorIncSentFn              func(cmdType string)
+	MetricsCollectorIncResultsFn           func(cmdType string)
+	MetricsCollectorIncErrorsFn            func(cmdType string)
+	MetricsCollectorIncTimeoutsFn          func(cmdType string)
+	MetricsCollectorObserveLatencyFn       func(cmdType string, latency time.Duration)
+	MetricsCollectorObserveBytesSentFn     func(cmdType string, n int)
+	MetricsCollectorObserveBytesReceivedFn func(cmdType string, n int)
+	MetricsCollectorAddInFlightFn          func(cmdType string, delta int)
+	MetricsCollectorIncCacheHitsFn         func(cmdType string)
+)
+
+func NewMetricsCollector() MetricsCollector {
+	return MetricsCollector{
+		Mock: mok.New("MetricsCollector"),
+	}
+}
+
+type MetricsCollector struct {
+	*mok.Mock
+}
+
+func (c MetricsCollector) RegisterIncSent(fn MetricsCollectorIncSentFn) MetricsCollector {
+	c.Register("IncSent", fn)
+	return c
+}
+
+func (c MetricsCollector) RegisterIncResults(fn MetricsCollectorIncResultsFn) MetricsCollector {
+	c.Register("IncResults", fn)
+	return c
+}
+
+func (c MetricsCollector) RegisterIncErrors(fn MetricsCollectorIncErrorsFn) MetricsCollector {
+	c.Register("IncErrors", fn)
+	return c
+}
+
+func (c MetricsCollector) RegisterIncTimeouts(fn MetricsCollectorIncTimeoutsFn) MetricsCollector {
+	c.Register("IncTimeouts", fn)
+	return c
+}
+
+func (c MetricsCollector) RegisterObserveLatency(fn MetricsCollectorObserveLatencyFn) MetricsCollector {
+	c.Register("ObserveLatency", fn)
+	return c
+}
+
+func (c MetricsCollector) RegisterObserveBytesSent(fn MetricsCollectorObserveBytesSentFn) MetricsCollector {
+	c.Register("ObserveBytesSent", fn)
+	return c
+}
+
+func (c MetricsCollector) RegisterObserveBytesReceived(fn MetricsCollectorObserveBytesReceivedFn) MetricsCollector {
+	c.Register("ObserveBytesReceived", fn)
+	return c
+}
+
+func (c MetricsCollector) RegisterAddInFlight(fn MetricsCollectorAddInFlightFn) MetricsCollector {
+	c.Register("AddInFlight", fn)
+	return c
+}
+
+func (c MetricsCollector) RegisterIncCacheHits(fn MetricsCollectorIncCacheHitsFn) MetricsCollector {
+	c.Register("IncCacheHits", fn)
+	return c
+}
+
+func (c MetricsCollector) IncSent(cmdType string) {
+	_, err := c.Call("IncSent", cmdType)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (c MetricsCollector) IncResults(cmdType string) {
+	_, err := c.Call("IncResults", cmdType)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (c MetricsCollector) IncErrors(cmdType string) {
+	_, err := c.Call("IncErrors", cmdType)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (c MetricsCollector) IncTimeouts(cmdType string) {
+	_, err := c.Call("IncTimeouts", cmdType)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (c MetricsCollector) ObserveLatency(cmdType string, latency time.Duration) {
+	_, err := c.Call("ObserveLatency", cmdType, latency)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (c MetricsCollector) ObserveBytesSent(cmdType string, n int) {
+	_, err := c.Call("ObserveBytesSent", cmdType, n)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (c MetricsCollector) ObserveBytesReceived(cmdType string, n int) {
+	_, err := c.Call("ObserveBytesReceived", cmdType, n)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (c MetricsCollector) AddInFlight(cmdType string, delta int) {
+	_, err := c.Call("AddInFlight", cmdType, delta)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (c MetricsCollector) IncCacheHits(cmdType string) {
+	_, err := c.Call("IncCacheHits", cmdType)
+	if err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,49 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/ymz-ncnk/mok"
+)
+
+type (
+	RateLimiterAllowFn func() bool
+	RateLimiterWaitFn  func(ctx context.Context) error
+)
+
+func NewRateLimiter() RateLimiter {
+	return RateLimiter{
+		Mock: mok.New("RateLimiter"),
+	}
+}
+
+type RateLimiter struct {
+	*mok.Mock
+}
+
+func (l RateLimiter) RegisterAllow(fn RateLimiterAllowFn) RateLimiter {
+	l.Register("Allow", fn)
+	return l
+}
+
+func (l RateLimiter) RegisterWait(fn RateLimiterWaitFn) RateLimiter {
+	l.Register("Wait", fn)
+	return l
+}
+
+func (l RateLimiter) Allow() bool {
+	result, err := l.Call("Allow")
+	if err != nil {
+		panic(err)
+	}
+	return result[0].(bool)
+}
+
+func (l RateLimiter) Wait(ctx context.Context) (err error) {
+	result, err := l.Call("Wait", ctx)
+	if err != nil {
+		panic(err)
+	}
+	err, _ = result[0].(error)
+	return
+}
@@ -31,6 +31,11 @@ func (h Hooks[T]) RegisterBeforeSend(fn BeforeSendFn[T]) Hooks[T] {
 	return h
 }
 
+func (h Hooks[T]) RegisterNBeforeSend(n int, fn BeforeSendFn[T]) Hooks[T] {
+	h.RegisterN("BeforeSend", n, fn)
+	return h
+}
+
 func (h Hooks[T]) RegisterOnError(fn OnErrorFn[T]) Hooks[T] {
 	h.Register("OnError", fn)
 	return h
@@ -41,6 +46,11 @@ func (h Hooks[T]) RegisterOnResult(fn OnResultFn[T]) Hooks[T] {
 	return h
 }
 
+func (h Hooks[T]) RegisterNOnResult(n int, fn OnResultFn[T]) Hooks[T] {
+	h.RegisterN("OnResult", n, fn)
+	return h
+}
+
 func (h Hooks[T]) RegisterOnTimeout(fn OnTimeoutFn[T]) Hooks[T] {
 	h.Register("OnTimeout", fn)
 	return h
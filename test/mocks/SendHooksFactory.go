@@ -22,6 +22,11 @@ func (m HooksFactory[T]) RegisterNew(fn HooksFactoryNewFn[T]) HooksFactory[T] {
 	return m
 }
 
+func (m HooksFactory[T]) RegisterNNew(n int, fn HooksFactoryNewFn[T]) HooksFactory[T] {
+	m.RegisterN("New", n, fn)
+	return m
+}
+
 func (m HooksFactory[T]) New() (hooks hks.Hooks[T]) {
 	result, err := m.Call("New")
 	if err != nil {
@@ -0,0 +1,158 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/cmd-stream/core-go"
+	sndr "github.com/cmd-stream/sender-go"
+	"github.com/ymz-ncnk/mok"
+)
+
+type (
+	SenderSendFn[T any] func(ctx context.Context, cmd core.Cmd[T]) (
+		result core.Result, err error)
+	SenderSendWithDeadlineFn[T any] func(ctx context.Context, cmd core.Cmd[T],
+		deadline time.Time) (result core.Result, err error)
+	SendMultiFn[T any] func(ctx context.Context, cmd core.Cmd[T],
+		resultsCount int, handler sndr.ResultHandler) (err error)
+	SendMultiWithDeadlineFn[T any] func(ctx context.Context, cmd core.Cmd[T],
+		resultsCount int, handler sndr.ResultHandler, deadline time.Time) (
+		err error)
+	CloseAndWaitFn func(timeout time.Duration) (err error)
+)
+
+func NewSender[T any]() Sender[T] {
+	return Sender[T]{
+		Mock: mok.New("Sender"),
+	}
+}
+
+type Sender[T any] struct {
+	*mok.Mock
+}
+
+func (s Sender[T]) RegisterSend(fn SenderSendFn[T]) Sender[T] {
+	s.Register("Send", fn)
+	return s
+}
+
+func (s Sender[T]) RegisterSendWithDeadline(
+	fn SenderSendWithDeadlineFn[T],
+) Sender[T] {
+	s.Register("SendWithDeadline", fn)
+	return s
+}
+
+func (s Sender[T]) RegisterSendMulti(fn SendMultiFn[T]) Sender[T] {
+	s.Register("SendMulti", fn)
+	return s
+}
+
+func (s Sender[T]) RegisterSendMultiWithDeadline(
+	fn SendMultiWithDeadlineFn[T],
+) Sender[T] {
+	s.Register("SendMultiWithDeadline", fn)
+	return s
+}
+
+func (s Sender[T]) RegisterClose(fn CloseFn) Sender[T] {
+	s.Register("Close", fn)
+	return s
+}
+
+func (s Sender[T]) RegisterDone(fn DoneFn) Sender[T] {
+	s.Register("Done", fn)
+	return s
+}
+
+func (s Sender[T]) RegisterCloseAndWait(fn CloseAndWaitFn) Sender[T] {
+	s.Register("CloseAndWait", fn)
+	return s
+}
+
+func (s Sender[T]) Send(ctx context.Context, cmd core.Cmd[T]) (
+	result core.Result, err error,
+) {
+	vals, err := s.Call("Send", mok.SafeVal[context.Context](ctx),
+		mok.SafeVal[core.Cmd[T]](cmd))
+	if err != nil {
+		panic(err)
+	}
+
+	result, _ = vals[0].(core.Result)
+	err, _ = vals[1].(error)
+	return
+}
+
+func (s Sender[T]) SendWithDeadline(ctx context.Context, cmd core.Cmd[T],
+	deadline time.Time,
+) (result core.Result, err error) {
+	vals, err := s.Call("SendWithDeadline", mok.SafeVal[context.Context](ctx),
+		mok.SafeVal[core.Cmd[T]](cmd), deadline)
+	if err != nil {
+		panic(err)
+	}
+
+	result, _ = vals[0].(core.Result)
+	err, _ = vals[1].(error)
+	return
+}
+
+func (s Sender[T]) SendMulti(ctx context.Context, cmd core.Cmd[T],
+	resultsCount int, handler sndr.ResultHandler,
+) (err error) {
+	vals, err := s.Call("SendMulti", mok.SafeVal[context.Context](ctx),
+		mok.SafeVal[core.Cmd[T]](cmd), resultsCount,
+		mok.SafeVal[sndr.ResultHandler](handler))
+	if err != nil {
+		panic(err)
+	}
+
+	err, _ = vals[0].(error)
+	return
+}
+
+func (s Sender[T]) SendMultiWithDeadline(ctx context.Context, cmd core.Cmd[T],
+	resultsCount int, handler sndr.ResultHandler, deadline time.Time,
+) (err error) {
+	vals, err := s.Call("SendMultiWithDeadline",
+		mok.SafeVal[context.Context](ctx), mok.SafeVal[core.Cmd[T]](cmd),
+		resultsCount, mok.SafeVal[sndr.ResultHandler](handler), deadline)
+	if err != nil {
+		panic(err)
+	}
+
+	err, _ = vals[0].(error)
+	return
+}
+
+func (s Sender[T]) Close() (err error) {
+	vals, err := s.Call("Close")
+	if err != nil {
+		panic(err)
+	}
+
+	err, _ = vals[0].(error)
+	return
+}
+
+func (s Sender[T]) Done() (ch <-chan struct{}) {
+	vals, err := s.Call("Done")
+	if err != nil {
+		panic(err)
+	}
+
+	ch, _ = vals[0].(<-chan struct{})
+	return
+}
+
+func (s Sender[T]) CloseAndWait(timeout time.Duration) (err error) {
+	vals, err := s.Call("CloseAndWait", timeout)
+	if err != nil {
+		panic(err)
+	}
+
+	err, _ = vals[0].(error)
+	return
+}
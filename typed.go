@@ -0,0 +1,86 @@
+package sender
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/cmd-stream/core-go"
+)
+
+// TypedResultHandler is like ResultHandler, but receives Results of the
+// specific type.
+type TypedResultHandler[R any] interface {
+	Handle(result R, err error) error
+}
+
+// TypedResultHandlerFn is a function type that implements the
+// TypedResultHandler interface.
+type TypedResultHandlerFn[R any] func(result R, err error) error
+
+func (fn TypedResultHandlerFn[R]) Handle(result R, err error) error {
+	return fn(result, err)
+}
+
+// SendAs is like SenderAPI.Send, but returns the Result of type R. If the
+// received Result has another type, UnexpectedResultTypeError is returned.
+func SendAs[T, R any](ctx context.Context, sender SenderAPI[T], cmd core.Cmd[T]) (
+	result R, err error,
+) {
+	r, err := sender.Send(ctx, cmd)
+	return as[R](r, err)
+}
+
+// SendWithDeadlineAs is like SenderAPI.SendWithDeadline, but returns the Result
+// of type R. If the received Result has another type,
+// UnexpectedResultTypeError is returned.
+func SendWithDeadlineAs[T, R any](ctx context.Context, sender SenderAPI[T],
+	cmd core.Cmd[T], deadline time.Time,
+) (result R, err error) {
+	r, err := sender.SendWithDeadline(ctx, cmd, deadline)
+	return as[R](r, err)
+}
+
+// SendMultiAs is like SenderAPI.SendMulti, but passes Results of type R to the
+// handler. Receiving stops with UnexpectedResultTypeError if a Result has
+// another type.
+func SendMultiAs[T, R any](ctx context.Context, sender SenderAPI[T],
+	cmd core.Cmd[T],
+	resultsCount int,
+	handler TypedResultHandler[R],
+) (err error) {
+	return sender.SendMulti(ctx, cmd, resultsCount, adaptHandler(handler))
+}
+
+// SendMultiWithDeadlineAs is like SenderAPI.SendMultiWithDeadline, but passes
+// Results of type R to the handler. Receiving stops with
+// UnexpectedResultTypeError if a Result has another type.
+func SendMultiWithDeadlineAs[T, R any](ctx context.Context, sender SenderAPI[T],
+	cmd core.Cmd[T],
+	resultsCount int,
+	handler TypedResultHandler[R],
+	deadline time.Time,
+) (err error) {
+	return sender.SendMultiWithDeadline(ctx, cmd, resultsCount,
+		adaptHandler(handler), deadline)
+}
+
+func as[R any](r core.Result, err error) (result R, aerr error) {
+	result, ok := r.(R)
+	if !ok && r != nil && err == nil {
+		err = NewUnexpectedResultTypeError(r, reflect.TypeFor[R]())
+	}
+	return result, err
+}
+
+func adaptHandler[R any](handler TypedResultHandler[R]) ResultHandlerFn {
+	return func(r core.Result, err error) error {
+		if r != nil && err == nil {
+			if _, ok := r.(R); !ok {
+				return NewUnexpectedResultTypeError(r, reflect.TypeFor[R]())
+			}
+		}
+		result, _ := r.(R)
+		return handler.Handle(result, err)
+	}
+}
@@ -0,0 +1,168 @@
+package sender_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	grp "github.com/cmd-stream/cmd-stream-go/group"
+	"github.com/cmd-stream/core-go"
+	sndr "github.com/cmd-stream/sender-go"
+	"github.com/cmd-stream/sender-go/test/mocks"
+	cmocks "github.com/cmd-stream/testkit-go/mocks/core"
+	asserterror "github.com/ymz-ncnk/assert/error"
+	"github.com/ymz-ncnk/mok"
+)
+
+type typedResult struct {
+	lastOne bool
+}
+
+func (r typedResult) LastOne() bool { return r.lastOne }
+
+func TestTyped(t *testing.T) {
+	t.Run("SendAs should return the typed Result", func(t *testing.T) {
+		var (
+			wantResult = typedResult{lastOne: true}
+			group      = mocks.NewClientGroup().RegisterSend(
+				func(cmd core.Cmd[any], results chan<- core.AsyncResult) (
+					seq core.Seq, clientID grp.ClientID, n int, err error,
+				) {
+					results <- core.AsyncResult{Result: wantResult}
+					return 1, 0, 10, nil
+				},
+			)
+			sender = sndr.New[any](group)
+			mocks  = []*mok.Mock{group.Mock}
+		)
+		result, err := sndr.SendAs[any, typedResult](context.Background(), sender,
+			cmocks.NewCmd())
+		asserterror.EqualError(err, nil, t)
+		asserterror.Equal(result, wantResult, t)
+
+		asserterror.EqualDeep(mok.CheckCalls(mocks), mok.EmptyInfomap, t)
+	})
+
+	t.Run("SendAs should accept a SenderAPI", func(t *testing.T) {
+		var (
+			wantResult = typedResult{lastOne: true}
+			sender     = mocks.NewSender[any]().RegisterSend(
+				func(ctx context.Context, cmd core.Cmd[any]) (core.Result, error) {
+					return wantResult, nil
+				},
+			)
+			mocks = []*mok.Mock{sender.Mock}
+		)
+		result, err := sndr.SendAs[any, typedResult](context.Background(), sender,
+			cmocks.NewCmd())
+		asserterror.EqualError(err, nil, t)
+		asserterror.Equal(result, wantResult, t)
+
+		asserterror.EqualDeep(mok.CheckCalls(mocks), mok.EmptyInfomap, t)
+	})
+
+	t.Run("SendWithDeadlineAs should return UnexpectedResultTypeError",
+		func(t *testing.T) {
+			var (
+				wantResult = cmocks.NewResult()
+				group      = mocks.NewClientGroup().RegisterSendWithDeadline(
+					func(cmd core.Cmd[any], results chan<- core.AsyncResult,
+						deadline time.Time,
+					) (seq core.Seq, clientID grp.ClientID, n int, err error) {
+						results <- core.AsyncResult{Result: wantResult}
+						return 1, 0, 10, nil
+					},
+				)
+				sender = sndr.New[any](group)
+				mocks  = []*mok.Mock{group.Mock}
+			)
+			_, err := sndr.SendWithDeadlineAs[any, typedResult](context.Background(),
+				sender, cmocks.NewCmd(), time.Now())
+			asserterror.Equal(errors.Is(err, sndr.ErrUnexpectedResultType), true, t)
+			var typeErr *sndr.UnexpectedResultTypeError
+			asserterror.Equal(errors.As(err, &typeErr), true, t)
+			asserterror.EqualDeep(typeErr.Result, core.Result(wantResult), t)
+			asserterror.Equal(typeErr.Want, reflect.TypeFor[typedResult](), t)
+
+			asserterror.EqualDeep(mok.CheckCalls(mocks), mok.EmptyInfomap, t)
+		})
+
+	t.Run("SendAs should return the send error", func(t *testing.T) {
+		var (
+			wantErr = errors.New("ClientGroup.Send error")
+			group   = mocks.NewClientGroup().RegisterSend(
+				func(cmd core.Cmd[any], results chan<- core.AsyncResult) (
+					seq core.Seq, clientID grp.ClientID, n int, err error,
+				) {
+					return 0, 0, 0, wantErr
+				},
+			)
+			sender = sndr.New[any](group)
+		)
+		_, err := sndr.SendAs[any, typedResult](context.Background(), sender,
+			cmocks.NewCmd())
+		asserterror.EqualError(err, wantErr, t)
+	})
+
+	t.Run("SendMultiAs should pass typed Results to the handler",
+		func(t *testing.T) {
+			var (
+				wantResults = []typedResult{{}, {lastOne: true}}
+				group       = mocks.NewClientGroup().RegisterSend(
+					func(cmd core.Cmd[any], results chan<- core.AsyncResult) (
+						seq core.Seq, clientID grp.ClientID, n int, err error,
+					) {
+						for i := range wantResults {
+							results <- core.AsyncResult{Result: wantResults[i]}
+						}
+						return 1, 0, 10, nil
+					},
+				)
+				sender  = sndr.New[any](group)
+				results []typedResult
+			)
+			err := sndr.SendMultiAs(context.Background(), sender, cmocks.NewCmd(), 2,
+				sndr.TypedResultHandlerFn[typedResult](
+					func(result typedResult, err error) error {
+						results = append(results, result)
+						return err
+					},
+				),
+			)
+			asserterror.EqualError(err, nil, t)
+			asserterror.EqualDeep(results, wantResults, t)
+		})
+
+	t.Run("SendMultiWithDeadlineAs should stop on an unexpected Result type",
+		func(t *testing.T) {
+			var (
+				group = mocks.NewClientGroup().RegisterSendWithDeadline(
+					func(cmd core.Cmd[any], results chan<- core.AsyncResult,
+						deadline time.Time,
+					) (seq core.Seq, clientID grp.ClientID, n int, err error) {
+						results <- core.AsyncResult{Result: typedResult{}}
+						results <- core.AsyncResult{Result: cmocks.NewResult().RegisterLastOne(
+							func() bool { return false },
+						)}
+						return 1, 0, 10, nil
+					},
+				).RegisterForget(func(seq core.Seq, clientID grp.ClientID) {})
+				sender = sndr.New[any](group)
+				count  int
+			)
+			err := sndr.SendMultiWithDeadlineAs(context.Background(), sender,
+				cmocks.NewCmd(), 2,
+				sndr.TypedResultHandlerFn[typedResult](
+					func(result typedResult, err error) error {
+						count++
+						return err
+					},
+				),
+				time.Now(),
+			)
+			asserterror.Equal(errors.Is(err, sndr.ErrUnexpectedResultType), true, t)
+			asserterror.Equal(count, 1, t)
+		})
+}